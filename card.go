@@ -3,6 +3,7 @@ package cribbage
 import (
 	"fmt"
 	"strconv"
+	"strings"
 )
 
 // Enumerations (Typed Constants)
@@ -96,3 +97,66 @@ func (s Suit) String() string {
 		return "?"
 	}
 }
+
+// Card from text such as "5♥", "10S", "TS" or "qd"
+func ParseCard(s string) (Card, error) {
+	s = strings.TrimSpace(s)
+	runes := []rune(s)
+	if len(runes) < 2 {
+		return Card{}, fmt.Errorf("invalid card %q", s)
+	}
+
+	var suit Suit
+	switch strings.ToUpper(string(runes[len(runes)-1])) {
+	case "C", "♣":
+		suit = Clubs
+	case "D", "♦":
+		suit = Diamonds
+	case "H", "♥":
+		suit = Hearts
+	case "S", "♠":
+		suit = Spades
+	default:
+		return Card{}, fmt.Errorf("invalid suit in card %q", s)
+	}
+
+	var rank Rank
+	switch r := strings.ToUpper(string(runes[:len(runes)-1])); r {
+	case "A":
+		rank = Ace
+	case "T":
+		rank = Ten
+	case "J":
+		rank = Jack
+	case "Q":
+		rank = Queen
+	case "K":
+		rank = King
+	default:
+		n, err := strconv.Atoi(r)
+		if err != nil || n < 2 || n > 10 {
+			return Card{}, fmt.Errorf("invalid rank in card %q", s)
+		}
+		rank = Rank(n)
+	}
+
+	color := Black
+	if suit == Diamonds || suit == Hearts {
+		color = Red
+	}
+	return Card{rank, suit, color}, nil
+}
+
+// Cards are saved as text, such as "5♥"
+func (c Card) MarshalText() ([]byte, error) {
+	return []byte(c.String()), nil
+}
+
+func (c *Card) UnmarshalText(text []byte) error {
+	card, err := ParseCard(string(text))
+	if err != nil {
+		return err
+	}
+	*c = card
+	return nil
+}
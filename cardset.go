@@ -0,0 +1,166 @@
+package cribbage
+
+// File contains a compact Card encoding and an allocation-free scorer for simulations
+
+import (
+	"iter"
+	"math/bits"
+)
+
+// Card index 0-51: Clubs A-K, Diamonds A-K, Hearts A-K, Spades A-K
+func (c Card) Index() int {
+	return int(c.Suit)*13 + int(c.Rank) - 1
+}
+
+func CardFromIndex(i int) Card {
+	suit := Suit(i / 13)
+	color := Black
+	if suit == Diamonds || suit == Hearts {
+		color = Red
+	}
+	return Card{Rank(i%13 + 1), suit, color}
+}
+
+// Set of Cards, bit i is the Card with Index i
+type CardSet uint64
+
+// every Card in a deck of 52
+const FullDeck CardSet = 1<<52 - 1
+
+// every Card of one Suit
+func SuitSet(s Suit) CardSet {
+	return CardSet(0x1FFF) << (13 * uint(s))
+}
+
+func (h Hand) Set() CardSet {
+	var s CardSet
+	for _, c := range h {
+		s |= 1 << c.Index()
+	}
+	return s
+}
+
+func (s CardSet) Add(c Card) CardSet {
+	return s | 1<<c.Index()
+}
+
+func (s CardSet) Remove(c Card) CardSet {
+	return s &^ (1 << c.Index())
+}
+
+func (s CardSet) Has(c Card) bool {
+	return s&(1<<c.Index()) != 0
+}
+
+func (s CardSet) Len() int {
+	return bits.OnesCount64(uint64(s))
+}
+
+// Cards in Index order
+func (s CardSet) All() iter.Seq[Card] {
+	return func(yield func(Card) bool) {
+		for ; s != 0; s &= s - 1 {
+			if !yield(CardFromIndex(bits.TrailingZeros64(uint64(s)))) {
+				return
+			}
+		}
+	}
+}
+
+func (s CardSet) Hand() Hand {
+	h := make(Hand, 0, s.Len())
+	for c := range s.All() {
+		h = append(h, c)
+	}
+	return h
+}
+
+// Same result as Hand.ScoreBreakdown for the Cards of the set, without allocating.
+// The cut must not be in the set.
+func (s CardSet) ScoreBreakdown(cut Card, isCrib bool) ScoreBreakdown {
+	var rankCount [14]int
+	// ways[v]: subsets of cards with values summing to v
+	var ways [16]int
+	ways[0] = 1
+
+	for c := range s.Add(cut).All() {
+		rankCount[c.Rank]++
+		v := c.ValueMax10()
+		for sum := 15; sum >= v; sum-- {
+			ways[sum] += ways[sum-v]
+		}
+	}
+
+	sb := ScoreBreakdown{}
+	sb.Fifteens = 2 * ways[15]
+
+	for _, n := range rankCount {
+		sb.Pairs += n * (n - 1)
+	}
+
+	// only one run of 3+ ranks fits in 5 cards
+	runLen, multiplier := 0, 1
+	for r := Ace; r <= King+1; r++ {
+		if r <= King && rankCount[r] > 0 {
+			runLen++
+			multiplier *= rankCount[r]
+			continue
+		}
+		if runLen >= 3 {
+			sb.Runs = runLen * multiplier
+			break
+		}
+		runLen, multiplier = 0, 1
+	}
+
+	sb.Flush, sb.Nobs = s.suitPoints(cut, isCrib)
+	sb.Total = sb.Fifteens + sb.Pairs + sb.Runs + sb.Flush + sb.Nobs
+	return sb
+}
+
+// Flush and Nobs, the only points that depend on Suits
+func (s CardSet) suitPoints(cut Card, isCrib bool) (flush, nobs int) {
+	// all hand cards share one Suit (see Score_flush)
+	if s != 0 {
+		for suit := Clubs; suit <= Spades; suit++ {
+			if s&^SuitSet(suit) != 0 {
+				continue
+			}
+			switch {
+			case cut.Suit == suit:
+				flush = 5
+			case !isCrib:
+				flush = 4
+			}
+		}
+	}
+
+	if s.Has(Card{Rank: Jack, Suit: cut.Suit}) {
+		nobs = 1
+	}
+	return
+}
+
+// Hands of 4 Cards are scored with the lookup table (see rankKey)
+func (s CardSet) Score(cut Card, isCrib bool) int {
+	return s.LookupBreakdown(cut, isCrib).Total
+}
+
+// ScoreBreakdown from the lookup table when the set holds 4 Cards
+func (s CardSet) LookupBreakdown(cut Card, isCrib bool) ScoreBreakdown {
+	if s.Len() != 4 {
+		return s.ScoreBreakdown(cut, isCrib)
+	}
+	var ranks [5]int
+	i := 0
+	for c := range s.All() {
+		ranks[i] = int(c.Rank)
+		i++
+	}
+	ranks[4] = int(cut.Rank)
+
+	sb := lookupRankBreakdown(ranks)
+	sb.Flush, sb.Nobs = s.suitPoints(cut, isCrib)
+	sb.Total = sb.Fifteens + sb.Pairs + sb.Runs + sb.Flush + sb.Nobs
+	return sb
+}
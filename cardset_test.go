@@ -0,0 +1,88 @@
+package cribbage
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestCardIndex_RoundTrip(t *testing.T) {
+	for i, card := range NewDeck() {
+		if card.Index() != i {
+			t.Fatalf("%s has index %d, want %d", card, card.Index(), i)
+		}
+		if CardFromIndex(i) != card {
+			t.Fatalf("CardFromIndex(%d) = %+v, want %+v", i, CardFromIndex(i), card)
+		}
+	}
+}
+
+// The bitmask scorer must agree with Hand.ScoreBreakdown on every category
+func TestCardSet_ScoreBreakdown(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	deck := NewDeck()
+
+	for range 5000 {
+		deck.Shuffle(rng)
+		hand := Hand(deck[:4])
+		set := hand.Set()
+		for _, cut := range deck[4:] {
+			for _, isCrib := range []bool{false, true} {
+				want := hand.ScoreBreakdown(cut, isCrib)
+				if got := set.ScoreBreakdown(cut, isCrib); got != want {
+					t.Fatalf("%s cut %s crib %t: got %+v, want %+v", hand, cut, isCrib, got, want)
+				}
+			}
+		}
+	}
+}
+
+func TestCardSet_ScoreBreakdownAllocs(t *testing.T) {
+	set := Hand{{Five, Clubs, Black}, {Five, Hearts, Red}, {Jack, Spades, Black}, {Four, Diamonds, Red}}.Set()
+	cut := Card{Six, Spades, Black}
+	allocs := testing.AllocsPerRun(100, func() {
+		set.ScoreBreakdown(cut, false)
+	})
+	if allocs != 0 {
+		t.Fatalf("got %f allocations, want 0", allocs)
+	}
+}
+
+func benchOption() DiscardOption {
+	dealt := Hand{
+		{Five, Clubs, Black}, {Five, Hearts, Red}, {Jack, Spades, Black},
+		{Four, Diamonds, Red}, {Nine, Clubs, Black}, {King, Hearts, Red},
+	}
+	return dealt.Split(4)[0]
+}
+
+// Inner loop of the discard analysis: score one Keep against all 46 cuts
+func BenchmarkCuts_Hand(b *testing.B) {
+	opt := benchOption()
+	remaining := difference(Hand(NewDeck()), append(opt.Keep, opt.Discard...))
+	for b.Loop() {
+		for _, cut := range remaining {
+			opt.Keep.ScoreBreakdown(cut, false)
+		}
+	}
+}
+
+func BenchmarkCuts_CardSet(b *testing.B) {
+	opt := benchOption()
+	keep := opt.Keep.Set()
+	remaining := FullDeck &^ (keep | opt.Discard.Set())
+	for b.Loop() {
+		for cut := range remaining.All() {
+			keep.ScoreBreakdown(cut, false)
+		}
+	}
+}
+
+func BenchmarkOptimalDiscard(b *testing.B) {
+	options := Hand{
+		{Five, Clubs, Black}, {Five, Hearts, Red}, {Jack, Spades, Black},
+		{Four, Diamonds, Red}, {Nine, Clubs, Black}, {King, Hearts, Red},
+	}.Split(4)
+	for b.Loop() {
+		OptimalDiscard(options, true)
+	}
+}
@@ -0,0 +1,15 @@
+// Command genscores writes the rank lookup table embedded by package cribbage.
+// Run with go generate from the module root.
+package main
+
+import (
+	"cribbage"
+	"log"
+	"os"
+)
+
+func main() {
+	if err := os.WriteFile("rankscores.bin", cribbage.BuildRankTable(), 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
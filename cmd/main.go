@@ -1,7 +1,105 @@
 package main
 
-import "cribbage"
+import (
+	"cribbage"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+)
 
 func main() {
-	cribbage.Start()
+	if len(os.Args) < 2 {
+		cribbage.Start()
+		return
+	}
+
+	switch os.Args[1] {
+	case "frequencies":
+		fs := flag.NewFlagSet("frequencies", flag.ExitOnError)
+		verify := fs.Bool("verify", true, "cross-check every combination with Hand.ScoreBreakdown")
+		fs.Parse(os.Args[2:])
+		cribbage.EnumerateScores(*verify).Print()
+	case "count":
+		fs := flag.NewFlagSet("count", flag.ExitOnError)
+		file := fs.String("file", dataFile("counting.json"), "saved counting statistics")
+		fs.Parse(os.Args[2:])
+		exitOnError(cribbage.CountingTrainer(*file))
+	case "discard":
+		fs := flag.NewFlagSet("discard", flag.ExitOnError)
+		file := fs.String("file", dataFile("discarding.json"), "saved discard results")
+		fs.Parse(os.Args[2:])
+		exitOnError(cribbage.DiscardTrainer(*file))
+	case "puzzle":
+		fs := flag.NewFlagSet("puzzle", flag.ExitOnError)
+		file := fs.String("file", dataFile("puzzles.json"), "pegging puzzles to load and save")
+		fs.Parse(os.Args[2:])
+		exitOnError(cribbage.PegPuzzleTrainer(*file))
+	case "pegging":
+		fs := flag.NewFlagSet("pegging", flag.ExitOnError)
+		pile := fs.String("pile", "", "cards of the current pile in play order, such as \"10S 5H\"")
+		hand := fs.String("hand", "", "your remaining cards")
+		count := fs.Int("count", 0, "pile count, when earlier cards of the pile are not listed")
+		played := fs.String("played", "", "cards of earlier piles this round")
+		cut := fs.String("cut", "", "the cut card")
+		opp := fs.Int("opp", -1, "cards left in the opponent's hand (default: as many as yours)")
+		fs.Parse(os.Args[2:])
+		puzzle, err := pegPosition(*pile, *hand, *played, *cut, *count, *opp)
+		exitOnError(err)
+		cribbage.PeggingAnalysis(puzzle.View(), 1000)
+	case "stats":
+		fs := flag.NewFlagSet("stats", flag.ExitOnError)
+		file := fs.String("file", dataFile(cribbage.ProfilesFile), "saved player profiles")
+		name := fs.String("name", "", "print only this player")
+		fs.Parse(os.Args[2:])
+		exitOnError(cribbage.PrintProfiles(*file, *name))
+	default:
+		fmt.Fprintf(os.Stderr, "unknown command %q\n", os.Args[1])
+		fmt.Fprintln(os.Stderr, "usage: cribbage [frequencies | count | discard | puzzle | pegging | stats]")
+		os.Exit(2)
+	}
+}
+
+// Pegging position from the flags of the pegging command
+func pegPosition(pile, hand, played, cut string, count, opp int) (cribbage.PegPuzzle, error) {
+	var p cribbage.PegPuzzle
+	var err error
+	if p.Pile, err = cribbage.ParseHand(pile); err != nil {
+		return p, err
+	}
+	if p.Hand, err = cribbage.ParseHand(hand); err != nil {
+		return p, err
+	}
+	if p.Played, err = cribbage.ParseHand(played); err != nil {
+		return p, err
+	}
+	if cut != "" {
+		card, err := cribbage.ParseCard(cut)
+		if err != nil {
+			return p, err
+		}
+		p.Cut = &card
+	}
+	p.Count = count
+	p.OppCards = opp
+	if opp < 0 {
+		p.OppCards = len(p.Hand)
+	}
+	return p, p.Validate()
+}
+
+// path of a file in the cribbage data directory
+func dataFile(name string) string {
+	dir, err := cribbage.DataDir()
+	if err != nil {
+		return name
+	}
+	return filepath.Join(dir, name)
+}
+
+func exitOnError(err error) {
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
 }
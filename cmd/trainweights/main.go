@@ -0,0 +1,27 @@
+// Command trainweights fits the crib weights of HeuristicScoreFloat on
+// simulated cribs and writes them as JSON.
+// Run with go generate from the module root to refresh the embedded weights.
+package main
+
+import (
+	"cribbage"
+	"flag"
+	"fmt"
+	"log"
+	"math/rand"
+)
+
+func main() {
+	samples := flag.Int("samples", 1000000, "simulated cribs")
+	seed := flag.Int64("seed", 1, "random seed")
+	out := flag.String("out", "cribweights.json", "weights file to write")
+	flag.Parse()
+
+	w := cribbage.TrainCribWeights(rand.New(rand.NewSource(*seed)), *samples)
+	if err := w.Save(*out); err != nil {
+		log.Fatal(err)
+	}
+	for _, name := range cribbage.CribFeatures {
+		fmt.Printf("%-8s %7.4f\n", name, w.Weights[name])
+	}
+}
@@ -3,7 +3,6 @@ package cribbage
 import (
 	"fmt"
 	"math/rand/v2"
-	"slices"
 )
 
 type ComputerPlayer struct {
@@ -11,6 +10,12 @@ type ComputerPlayer struct {
 	Hand    Hand
 	PegHand Hand
 	Points  int
+	// pegging plays by tree search instead of PositionalPegging, may be nil.
+	// Unused when Personality is set.
+	Search *ISMCTS
+	// plays like a person with this Personality, may be nil.
+	// It decides the discards, pegging and counts, ahead of Search.
+	Personality *Personality
 }
 
 func (p *ComputerPlayer) String() string {
@@ -38,9 +43,18 @@ func (p *ComputerPlayer) AddPoints(n int) int {
 	return p.Points
 }
 
-func (p *ComputerPlayer) Discard(isDealer bool) (discard Hand, keep Hand) {
+func (p *ComputerPlayer) ResetPoints() {
+	p.Points = 0
+}
+
+func (p *ComputerPlayer) Discard(view PlayerView) (discard Hand, keep Hand) {
 	options := p.Hand.Split(4)
-	best := OptimalDiscard(options, isDealer)
+	var best DiscardOption
+	if p.Personality != nil {
+		best = p.Personality.Discard(options, view.IsDealer(), p.Personality.Rand())
+	} else {
+		best = PositionalDiscard(options, view, DefaultWinModel())
+	}
 	discard = best.Discard
 	keep = best.Keep
 
@@ -51,43 +65,53 @@ func (p *ComputerPlayer) Discard(isDealer bool) (discard Hand, keep Hand) {
 	return
 }
 
-func (p *ComputerPlayer) PlayPegCard(s PegState) (cardToPlay Card, passed bool) {
-	best, ok := OptimalPegging(s, p.PegHand)
+// Pegging by the Personality, else by Search, else by PositionalPegging
+func (p *ComputerPlayer) PlayPegCard(view PlayerView) (cardToPlay Card, passed bool) {
+	if p.Personality != nil {
+		card, ok := p.Personality.PegPlay(view, p.Personality.Rand())
+		return card, !ok
+	}
+	if p.Search != nil {
+		card, ok := p.Search.Choose(view)
+		return card, !ok
+	}
+	// score-aware play against the inferred opponent hand,
+	// ok is false only without a legal Card
+	belief := InferOpponentHand(view, beliefSamples, view.Rand())
+	best, ok := PositionalPegging(view, DefaultWinModel(), belief)
 	if ok {
 		cardToPlay = best
 		passed = false
 		return
 	}
-
-	// else no optimal detected, play the first valid one
-	for i, card := range p.PegHand {
-		if card.ValueMax10() <= 31-s.Sum {
-			p.PegHand = slices.Delete(p.PegHand, i, i+1)
-			cardToPlay = card
-			passed = false
-			return
-		}
-	}
 	// no valid card and say Go/pass
 	cardToPlay = Card{}
 	passed = true
 	return
 }
 
-func (p *ComputerPlayer) DrawCard() int {
-	return rand.IntN(52)
+func (p *ComputerPlayer) DrawCard(n int) int {
+	return rand.IntN(n)
 }
 
 func (p *ComputerPlayer) CountHand(cut Card, isCrib bool) int {
-	points := p.Hand.ScoreBreakdown(cut, isCrib)
+	items := p.Hand.Itemize(cut, isCrib)
+	if p.Personality != nil {
+		items = p.Personality.Count(p.Hand, cut, isCrib, p.Personality.Rand())
+	}
 	if isCrib {
 		fmt.Printf("%s (Crib): %s", p.Name, p.Hand)
 	} else {
 		fmt.Printf("%s: %s", p.Name, p.Hand)
 	}
-	fmt.Printf(" (%d points)\n", points.Total)
-	points.Print()
-	return points.Total
+	fmt.Printf(" (%d points)\n", items.Total())
+	items.Print()
+	return items.Total()
+}
+
+// A computer never misses points in the opponent's count
+func (p *ComputerPlayer) CallMuggins(hand Hand, cut Card, isCrib bool, claimed int) int {
+	return max(0, hand.Score(cut, isCrib)-claimed)
 }
 
 func (p *ComputerPlayer) EnterToContinue() {
@@ -98,3 +122,11 @@ func (p *ComputerPlayer) EnterToContinue() {
 func (p *ComputerPlayer) EmptyPegHand() bool {
 	return len(p.PegHand) == 0
 }
+
+func (p *ComputerPlayer) GetPegHand() Hand {
+	return p.PegHand
+}
+
+func (p *ComputerPlayer) SetPegHand(h Hand) {
+	p.PegHand = h
+}
@@ -0,0 +1,419 @@
+package cribbage
+
+// File contains free-form count entry: a count typed the way it is spoken,
+// such as "15-2, 15-4, pair is 6, run of 3 is 9, nobs 10", or a single total
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Points claimed by a Player. With TotalOnly just Points.Total was given.
+type CountClaim struct {
+	Points    ScoreBreakdown
+	TotalOnly bool
+	// the claimed runs: how many and of what length, both 0 without runs
+	RunCount, RunLength int
+}
+
+// number words up to twenty-nine, "fifteen" included
+func parseNumberWord(word string) (int, bool) {
+	if n, err := strconv.Atoi(word); err == nil {
+		return n, true
+	}
+	for n, w := range numberWords {
+		if w == word {
+			return n, true
+		}
+	}
+	return 0, false
+}
+
+// a word of one phrase, num is its value as a number or -1
+type countToken struct {
+	word string
+	num  int
+}
+
+func tokenizeCount(phrase string) []countToken {
+	var tokens []countToken
+	words := strings.Fields(phrase)
+	for i := 0; i < len(words); i++ {
+		word := words[i]
+		if n, ok := parseNumberWord(word); ok {
+			// "twenty one" was "twenty-one" before hyphens became spaces
+			if n == 20 && i+1 < len(words) {
+				if unit, ok := parseNumberWord(words[i+1]); ok && unit > 0 && unit < 10 {
+					n += unit
+					i++
+				}
+			}
+			tokens = append(tokens, countToken{word, n})
+			continue
+		}
+		tokens = append(tokens, countToken{word, -1})
+	}
+	return tokens
+}
+
+// Walks the tokens of a count, one item at a time
+type countParser struct {
+	tokens []countToken
+	pos    int
+	sb     ScoreBreakdown
+	// pairs claimed alone and pairs claimed inside double or triple runs
+	pairs, runPairs     int
+	runCount, runLength int
+}
+
+func (p *countParser) peek(k int) countToken {
+	if p.pos+k < len(p.tokens) {
+		return p.tokens[p.pos+k]
+	}
+	return countToken{"", -1}
+}
+
+// consume the next token when its word is one of words
+func (p *countParser) accept(words ...string) bool {
+	for _, w := range words {
+		if p.peek(0).num < 0 && p.peek(0).word == w {
+			p.pos++
+			return true
+		}
+	}
+	return false
+}
+
+func isCountSeparator(t countToken) bool {
+	return t.word == "," || t.word == "and" || t.word == "plus" || t.word == "then"
+}
+
+// ranks a pair can be "of": "fives", "5s" or "5"
+func isRankWord(t countToken) bool {
+	if t.num > 0 && t.num <= 10 {
+		return true
+	}
+	switch t.word {
+	case "aces", "twos", "threes", "fours", "fives", "sixes", "sevens", "eights",
+		"nines", "tens", "jacks", "queens", "kings":
+		return true
+	}
+	n, ok := parseNumberWord(strings.TrimSuffix(t.word, "s"))
+	return ok && n > 0 && n <= 10
+}
+
+// words that start an item after a multiplier or modifier
+func isCountItem(t countToken) bool {
+	switch t.word {
+	case "fifteens", "15s", "pair", "pairs", "run", "runs", "flush", "double", "triple", "quadruple":
+		return true
+	}
+	return strings.HasPrefix(t.word, "nob") || strings.HasPrefix(t.word, "knob")
+}
+
+// text of the tokens from the current one up to the next separator
+func (p *countParser) rest() string {
+	var words []string
+	for i := p.pos; i < len(p.tokens) && !isCountSeparator(p.tokens[i]); i++ {
+		words = append(words, p.tokens[i].word)
+	}
+	return strings.Join(words, " ")
+}
+
+// Parse a spoken count into claimed points. Items may be separated by commas,
+// "and" or nothing at all; the running totals ("is 9", "nobs 10") are ignored.
+// A lone number, even 15, is a total.
+//
+//	fifteens: "15-2", "fifteen four", "3 fifteens for 6"
+//	pairs:    "pair is 6", "2 pairs", "a pair of fives", "pair royal", "double pair royal"
+//	runs:     "run of 3", "2 runs of 4", "double run of 3" (with its pair),
+//	          "triple run of 3", "double double run of 3"
+//	flush:    "flush", "flush of 5"
+//	nobs:     "nobs", "one for his nobs"
+//	points:   "and 2 is 4" for a pair, "and 1 is 5" for nobs
+//	nothing:  "nineteen", "none", "0"
+func ParseCount(input string) (CountClaim, error) {
+	input = strings.ToLower(strings.TrimSpace(input))
+	input = strings.NewReplacer("-", " ", ".", " ", ":", " ", ";", " , ", ",", " , ").Replace(input)
+	tokens := tokenizeCount(input)
+
+	var words []countToken
+	for _, t := range tokens {
+		if !isCountSeparator(t) {
+			words = append(words, t)
+		}
+	}
+	if len(words) == 0 {
+		return CountClaim{}, fmt.Errorf("enter a count")
+	}
+	if len(words) == 1 {
+		only := words[0]
+		switch {
+		case only.word == "nineteen" || only.word == "none" || only.word == "nothing" || only.num == 0:
+			// no points in any category
+			return CountClaim{}, nil
+		case only.num > 0:
+			return CountClaim{Points: ScoreBreakdown{Total: only.num}, TotalOnly: true}, nil
+		}
+	}
+
+	p := &countParser{tokens: tokens}
+	for p.pos < len(p.tokens) {
+		if isCountSeparator(p.peek(0)) {
+			p.pos++
+			continue
+		}
+		if p.accept("a") {
+			continue
+		}
+		if err := p.item(); err != nil {
+			return CountClaim{}, err
+		}
+		p.runningTotal()
+	}
+	if p.pairs > 0 && p.runPairs > 0 {
+		return CountClaim{}, fmt.Errorf("the pairs of a double or triple run are already counted, do not say them again")
+	}
+
+	sb := p.sb
+	sb.Total = sb.Fifteens + sb.Pairs + sb.Runs + sb.Flush + sb.Nobs
+	return CountClaim{Points: sb, RunCount: p.runCount, RunLength: p.runLength}, nil
+}
+
+// points claimed so far
+func (p *countParser) total() int {
+	return p.sb.Fifteens + p.sb.Pairs + p.sb.Runs + p.sb.Flush + p.sb.Nobs
+}
+
+// skip the running total after an item: "is 9", "for 6", or a number
+// that ends the input or a phrase
+func (p *countParser) runningTotal() {
+	if p.peek(1).num >= 0 && p.accept("is", "for", "makes", "equals", "=") {
+		p.pos++
+		return
+	}
+	if p.peek(0).num >= 0 && (p.peek(1).word == "" || isCountSeparator(p.peek(1))) {
+		p.pos++
+	}
+}
+
+// one scoring item, such as "15-2", "2 pairs" or "double run of 3"
+func (p *countParser) item() error {
+	text := p.rest()
+	start := p.pos
+
+	// "15 2" and "fifteen for four"
+	if p.peek(0).num == 15 {
+		next := 1
+		if p.peek(1).word == "for" {
+			next = 2
+		}
+		if p.peek(next).num >= 0 {
+			p.pos += next + 1
+			p.sb.Fifteens += 2
+			return nil
+		}
+	}
+
+	// points without a name, "2 is 4": a pair, or 1 for nobs
+	if t := p.peek(0); (t.num == 1 || t.num == 2) && p.peek(2).num >= 0 {
+		switch p.peek(1).word {
+		case "is", "for", "makes", "equals", "=":
+			if p.peek(2).num != p.total()+t.num {
+				return fmt.Errorf("%q does not add up to the count so far, %d", text, p.total())
+			}
+			p.pos++
+			if t.num == 2 {
+				p.sb.Pairs += 2
+				p.pairs++
+			} else {
+				p.sb.Nobs++
+			}
+			return nil
+		}
+	}
+
+	// a multiplier such as "2 pairs", or the "one" of "one for his nobs"
+	n := 1
+	if t := p.peek(0); t.num > 0 && isCountItem(p.peek(1)) {
+		n = t.num
+		p.pos++
+	} else if t.num == 1 {
+		p.pos++
+	}
+	doubles, triple := 0, false
+	for {
+		switch {
+		case p.accept("double"):
+			doubles++
+			continue
+		case p.accept("triple"):
+			triple = true
+			continue
+		case p.accept("quadruple"):
+			doubles = 2
+			continue
+		}
+		break
+	}
+	for p.accept("for", "his", "her", "the") {
+	}
+
+	switch t := p.peek(0); {
+	case t.word == "fifteens" || t.word == "15s":
+		p.pos++
+		p.sb.Fifteens += 2 * n
+
+	case t.word == "pair" || t.word == "pairs":
+		p.pos++
+		pairs := n
+		royal := p.accept("royal")
+		if p.peek(0).word == "of" && isRankWord(p.peek(1)) {
+			p.pos += 2
+		}
+		switch {
+		case royal && doubles > 0:
+			pairs = 6
+		case royal:
+			pairs = 3
+		case doubles > 0:
+			pairs = 2 * n
+		}
+		p.sb.Pairs += 2 * pairs
+		p.pairs += pairs
+
+	case t.word == "run" || t.word == "runs":
+		p.pos++
+		p.accept("of")
+		length := p.peek(0).num
+		if length < 3 || length > 5 {
+			return fmt.Errorf("say the length of the run in %q, such as \"run of 3\"", text)
+		}
+		p.pos++
+		runs, pairs := n, 0
+		switch {
+		case doubles >= 2:
+			runs, pairs = 4, 2
+		case triple:
+			runs, pairs = 3, 3
+		case doubles == 1:
+			runs, pairs = 2, 1
+		}
+		if p.runLength != 0 && p.runLength != length {
+			return fmt.Errorf("runs of %d and %d cannot score in one hand", p.runLength, length)
+		}
+		p.runCount += runs
+		p.runLength = length
+		p.sb.Runs += runs * length
+		p.sb.Pairs += 2 * pairs
+		p.runPairs += pairs
+
+	case t.word == "flush":
+		p.pos++
+		p.accept("of")
+		points := 4
+		if f := p.peek(0).num; f == 4 || f == 5 {
+			points = f
+			p.pos++
+		}
+		p.sb.Flush += points
+
+	case t.num < 0 && (strings.HasPrefix(t.word, "nob") || strings.HasPrefix(t.word, "knob")):
+		p.pos++
+		p.sb.Nobs++
+
+	default:
+		p.pos = start
+		return fmt.Errorf("cannot read %q", text)
+	}
+	return nil
+}
+
+// Ask the user to type the count of a hand, until it can be parsed
+func AskCount(hand Hand, cut Card, isCrib bool) CountClaim {
+	reader := bufio.NewReader(os.Stdin)
+	ClearScreen()
+	msg := " Count your points "
+	if isCrib {
+		msg = " (Crib)" + msg
+	}
+	fmt.Println(msg)
+	fmt.Println(strings.Repeat("-", len(msg)))
+	fmt.Printf("Hand: %s  Cut: %s\n\n", hand, cut)
+	fmt.Println("Count the way it is spoken, such as \"15-2, 15-4, pair is 6, run of 3 is 9, nobs 10\",")
+	fmt.Println("or enter a single total. Say \"nineteen\" for no points.")
+
+	for {
+		fmt.Print("Count: ")
+		input, err := reader.ReadString('\n')
+		if err != nil && input == "" {
+			return CountClaim{}
+		}
+		claim, err := ParseCount(input)
+		if err == nil {
+			return claim
+		}
+		fmt.Printf("Invalid input: %v.\n", err)
+	}
+}
+
+// Compare a claimed count with the real scoring items, with console messages.
+// Runs are compared by their number and length, not only by their points.
+func CompareCount(claim CountClaim, items ScoreItems) bool {
+	realPoints := items.Breakdown()
+	if claim.TotalOnly {
+		if claim.Points.Total != realPoints.Total {
+			fmt.Printf("Your hand had %d points, not %d\n", realPoints.Total, claim.Points.Total)
+			return false
+		}
+		return true
+	}
+	countedCorrect := CompareBreakDown(claim.Points, realPoints)
+	return compareRuns(claim, items) && countedCorrect
+}
+
+// the claimed runs against the runs of the items
+func compareRuns(claim CountClaim, items ScoreItems) bool {
+	var runs []Hand
+	for _, item := range items {
+		if item.Category == "Runs" {
+			runs = append(runs, item.Cards)
+		}
+	}
+	count, length := len(runs), 0
+	if count > 0 {
+		length = len(runs[0])
+	}
+	if count == claim.RunCount && length == claim.RunLength {
+		return true
+	}
+	fmt.Printf("Your hand had %s, not %s", runsPhrase(count, length), runsPhrase(claim.RunCount, claim.RunLength))
+	for i, run := range runs {
+		if i == 0 {
+			fmt.Print(":")
+		}
+		fmt.Printf(" %s", run)
+	}
+	fmt.Println()
+	return false
+}
+
+// spoken name of count runs of length, such as "a double run of 3"
+func runsPhrase(count, length int) string {
+	switch count {
+	case 0:
+		return "no runs"
+	case 1:
+		return fmt.Sprintf("a run of %d", length)
+	case 2:
+		return fmt.Sprintf("a double run of %d", length)
+	case 3:
+		return fmt.Sprintf("a triple run of %d", length)
+	case 4:
+		return fmt.Sprintf("a double double run of %d", length)
+	}
+	return fmt.Sprintf("%d runs of %d", count, length)
+}
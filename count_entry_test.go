@@ -0,0 +1,94 @@
+package cribbage
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseCount(t *testing.T) {
+	tests := []struct {
+		input string
+		want  ScoreBreakdown
+		total bool
+	}{
+		{"15-2, 15-4, pair is 6, run of 3 is 9, nobs 10", ScoreBreakdown{4, 2, 3, 0, 1, 10}, false},
+		{"fifteen two and a pair is four", ScoreBreakdown{2, 2, 0, 0, 0, 4}, false},
+		{"3 fifteens for 6, double run of 3 is 14", ScoreBreakdown{6, 2, 6, 0, 0, 14}, false},
+		{"15 2, 15 4, 15 6, 15 8, double double run of three is 24", ScoreBreakdown{8, 4, 12, 0, 0, 24}, false},
+		{"pair royal, flush of 5", ScoreBreakdown{0, 6, 0, 5, 0, 11}, false},
+		{"2 runs of 4 is 8", ScoreBreakdown{0, 0, 8, 0, 0, 8}, false},
+		// items without commas
+		{"15-2 15-4 pair is 6", ScoreBreakdown{4, 2, 0, 0, 0, 6}, false},
+		{"fifteen two fifteen four double run of three is twelve", ScoreBreakdown{4, 2, 6, 0, 0, 12}, false},
+		{"one for his nobs", ScoreBreakdown{0, 0, 0, 0, 1, 1}, false},
+		{"a pair of fives is 2", ScoreBreakdown{0, 2, 0, 0, 0, 2}, false},
+		{"15-2 and 2 is 4", ScoreBreakdown{2, 2, 0, 0, 0, 4}, false},
+		{"15-2, 15-4 and 1 is 5", ScoreBreakdown{4, 0, 0, 0, 1, 5}, false},
+		{"nineteen", ScoreBreakdown{}, false},
+		{"twenty-four", ScoreBreakdown{Total: 24}, true},
+		{"12", ScoreBreakdown{Total: 12}, true},
+	}
+	for _, tt := range tests {
+		claim, err := ParseCount(tt.input)
+		if err != nil || claim.Points != tt.want || claim.TotalOnly != tt.total {
+			t.Fatalf("ParseCount(%q) = %+v, %v, want %+v", tt.input, claim, err, tt.want)
+		}
+	}
+
+	for _, input := range []string{"", "run is 3", "fifteen-two, banana", "15-2 banana pair",
+		// the pair of a double run said again
+		"pair is 2, double run of 3 is 10",
+		// a bare number that does not add up, and two lengths of run
+		"15-2 and 2 is 5", "run of 3, run of 4"} {
+		if _, err := ParseCount(input); err == nil {
+			t.Fatalf("ParseCount(%q) accepted", input)
+		}
+	}
+}
+
+// Runs are compared by their number and length, not only their points
+func TestCompareCount_Runs(t *testing.T) {
+	// a double double run of 3 has the points of 3 runs of 4 and 2 pairs
+	hand, _ := ParseHand("3H 3S 4D 4C")
+	cut, _ := ParseCard("5H")
+	items := hand.Itemize(cut, false)
+
+	tests := []struct {
+		input string
+		want  bool
+	}{
+		{"15-2, 15-4, double double run of 3 is 20", true},
+		{"15-2, 15-4, 2 pairs is 8, 4 runs of 3 is 20", true},
+		{"15-2, 15-4, 2 pairs is 8, 3 runs of 4 is 20", false},
+		{"20", true},
+	}
+	for _, tt := range tests {
+		claim, err := ParseCount(tt.input)
+		if err != nil {
+			t.Fatalf("ParseCount(%q): %v", tt.input, err)
+		}
+		if got := CompareCount(claim, items); got != tt.want {
+			t.Fatalf("CompareCount(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+}
+
+// The call-out of every hand reads back as its breakdown
+func TestParseCount_CallOut(t *testing.T) {
+	hands := []string{"5H 5S 5D JC", "4H 5S 6D 6C", "2C 3C 4C 9C", "7H 8H 8S 9D"}
+	cut, _ := ParseCard("5C")
+	for _, text := range hands {
+		hand, _ := ParseHand(text)
+		items := hand.Itemize(cut, false)
+		// the call-out lines hold the cards after the words, keep only the words
+		var calls []string
+		for i, line := range items.CallOut() {
+			calls = append(calls, strings.TrimSpace(strings.TrimSuffix(line, items[i].Cards.String())))
+		}
+		spoken := strings.Join(calls, ", ")
+		claim, err := ParseCount(spoken)
+		if err != nil || claim.Points != hand.ScoreBreakdown(cut, false) || !CompareCount(claim, items) {
+			t.Fatalf("%s: ParseCount(%q) = %+v, %v", text, spoken, claim.Points, err)
+		}
+	}
+}
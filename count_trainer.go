@@ -0,0 +1,193 @@
+package cribbage
+
+// File contains the counting trainer: endless hand counting practice with saved statistics
+
+import (
+	"bufio"
+	"fmt"
+	"math/rand"
+	"os"
+	"strings"
+	"time"
+)
+
+// Point categories of a ScoreBreakdown, in display order
+var Categories = []string{"Fifteens", "Pairs", "Runs", "Flush", "Nobs"}
+
+func (sb ScoreBreakdown) Category(name string) int {
+	switch name {
+	case "Fifteens":
+		return sb.Fifteens
+	case "Pairs":
+		return sb.Pairs
+	case "Runs":
+		return sb.Runs
+	case "Flush":
+		return sb.Flush
+	case "Nobs":
+		return sb.Nobs
+	default:
+		return 0
+	}
+}
+
+type CategoryStats struct {
+	Attempts int
+	Correct  int
+}
+
+// Laplace-smoothed accuracy, 0.5 before any attempt
+func (c CategoryStats) Accuracy() float64 {
+	return float64(c.Correct+1) / float64(c.Attempts+2)
+}
+
+// Counting accuracy saved across sessions
+type CountingStats struct {
+	Problems   int
+	Seconds    float64 // total answer time
+	Categories map[string]CategoryStats
+}
+
+func NewCountingStats() *CountingStats {
+	return &CountingStats{Categories: make(map[string]CategoryStats)}
+}
+
+// Stats from a JSON file, or empty stats if the file does not exist yet
+func LoadCountingStats(path string) (*CountingStats, error) {
+	stats := NewCountingStats()
+	if _, err := loadJSON(path, stats); err != nil {
+		return nil, err
+	}
+	if stats.Categories == nil {
+		stats.Categories = make(map[string]CategoryStats)
+	}
+	return stats, nil
+}
+
+func (s *CountingStats) Save(path string) error {
+	return saveJSON(path, s)
+}
+
+// Update each category that scored (or was claimed) in the problem
+func (s *CountingStats) Record(userPoints, realPoints ScoreBreakdown, elapsed time.Duration) {
+	s.Problems++
+	s.Seconds += elapsed.Seconds()
+	for _, name := range Categories {
+		claimed, real := userPoints.Category(name), realPoints.Category(name)
+		if claimed == 0 && real == 0 {
+			continue
+		}
+		correct := claimed == real
+
+		c := s.Categories[name]
+		c.Attempts++
+		if correct {
+			c.Correct++
+		}
+		s.Categories[name] = c
+	}
+}
+
+// Update the "Total" entry for a count given as a single total
+func (s *CountingStats) RecordTotal(claimed, real int, elapsed time.Duration) {
+	s.Problems++
+	s.Seconds += elapsed.Seconds()
+	c := s.Categories["Total"]
+	c.Attempts++
+	if claimed == real {
+		c.Correct++
+	}
+	s.Categories["Total"] = c
+}
+
+// Pick a category with weight 1 - accuracy, so mistakes come back more often
+func (s *CountingStats) WeakCategory(rng *rand.Rand) string {
+	weights := make([]float64, len(Categories))
+	total := 0.0
+	for i, name := range Categories {
+		weights[i] = 1 - s.Categories[name].Accuracy()
+		total += weights[i]
+	}
+	pick := rng.Float64() * total
+	for i, w := range weights {
+		if pick < w {
+			return Categories[i]
+		}
+		pick -= w
+	}
+	return Categories[len(Categories)-1]
+}
+
+// Deal a hand and cut (a crib one time in four) that scores in the chosen category
+func (s *CountingStats) NextProblem(rng *rand.Rand) (hand Hand, cut Card, isCrib bool) {
+	target := s.WeakCategory(rng)
+	isCrib = rng.Intn(4) == 0
+	deck := NewDeck()
+	for range 5000 {
+		deck.Shuffle(rng)
+		hand, cut = Hand(deck[:4]), deck[4]
+		if hand.ScoreBreakdown(cut, isCrib).Category(target) > 0 {
+			break
+		}
+	}
+	return append(Hand{}, hand...), cut, isCrib
+}
+
+func (s *CountingStats) Print() {
+	msg := "--- COUNTING ACCURACY ---"
+	fmt.Println(msg)
+	if s.Problems > 0 {
+		fmt.Printf("%d problems, %.1f seconds per answer\n", s.Problems, s.Seconds/float64(s.Problems))
+	}
+	for _, name := range append(Categories[:len(Categories):len(Categories)], "Total") {
+		c := s.Categories[name]
+		if c.Attempts == 0 {
+			fmt.Printf("%-9s no attempts\n", name)
+			continue
+		}
+		fmt.Printf("%-9s %3d / %-3d (%.0f%%)\n", name, c.Correct, c.Attempts, 100*float64(c.Correct)/float64(c.Attempts))
+	}
+	fmt.Println(strings.Repeat("-", len(msg)))
+}
+
+// Practice counting until the user quits, saving statistics to path after every problem
+func CountingTrainer(path string) error {
+	stats, err := LoadCountingStats(path)
+	if err != nil {
+		return err
+	}
+	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+	reader := bufio.NewReader(os.Stdin)
+
+	for {
+		hand, cut, isCrib := stats.NextProblem(rng)
+		start := time.Now()
+		claim := AskCount(hand, cut, isCrib)
+		elapsed := time.Since(start)
+
+		items := hand.Itemize(cut, isCrib)
+		realPoints := items.Breakdown()
+		if CompareCount(claim, items) {
+			fmt.Println("You counted all points correctly!")
+		}
+		fmt.Printf("\n%s  Cut: %s (%d points) in %.1f seconds\n", hand, cut, realPoints.Total, elapsed.Seconds())
+		items.Print()
+
+		if claim.TotalOnly {
+			stats.RecordTotal(claim.Points.Total, realPoints.Total, elapsed)
+		} else {
+			stats.Record(claim.Points, realPoints, elapsed)
+		}
+		if err := stats.Save(path); err != nil {
+			return err
+		}
+		fmt.Println()
+		stats.Print()
+
+		fmt.Print("\nPress Enter for the next problem, or 'q' to quit: ")
+		input, err := reader.ReadString('\n')
+		if err != nil || strings.ToLower(strings.TrimSpace(input)) == "q" {
+			return nil
+		}
+	}
+}
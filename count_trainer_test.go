@@ -0,0 +1,57 @@
+package cribbage
+
+import (
+	"math/rand"
+	"path/filepath"
+	"testing"
+)
+
+func TestCountingStats_RecordAndSave(t *testing.T) {
+	stats := NewCountingStats()
+	// 3 4 5 with 2 fifteens: user found the fifteens and claimed 2 runs of 3
+	real := ScoreBreakdown{Fifteens: 4, Runs: 3, Total: 7}
+	user := ScoreBreakdown{Fifteens: 4, Runs: 6}
+	stats.Record(user, real, 0)
+
+	if c := stats.Categories["Fifteens"]; c.Attempts != 1 || c.Correct != 1 {
+		t.Fatalf("Fifteens %+v", c)
+	}
+	if c := stats.Categories["Runs"]; c.Attempts != 1 || c.Correct != 0 {
+		t.Fatalf("Runs %+v", c)
+	}
+	if _, ok := stats.Categories["Flush"]; ok {
+		t.Fatalf("unscored Flush was recorded")
+	}
+
+	path := filepath.Join(t.TempDir(), "counting.json")
+	if err := stats.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := LoadCountingStats(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.Problems != 1 || loaded.Categories["Runs"] != stats.Categories["Runs"] {
+		t.Fatalf("loaded %+v", loaded)
+	}
+}
+
+// Missed categories are picked more often
+func TestCountingStats_WeakCategory(t *testing.T) {
+	stats := NewCountingStats()
+	for _, name := range Categories {
+		stats.Categories[name] = CategoryStats{Attempts: 50, Correct: 50}
+	}
+	stats.Categories["Nobs"] = CategoryStats{Attempts: 50, Correct: 0}
+
+	rng := rand.New(rand.NewSource(1))
+	nobs := 0
+	for range 100 {
+		if stats.WeakCategory(rng) == "Nobs" {
+			nobs++
+		}
+	}
+	if nobs < 80 {
+		t.Fatalf("Nobs picked %d of 100 times", nobs)
+	}
+}
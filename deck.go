@@ -34,6 +34,12 @@ func (d Deck) Shuffle(rng *rand.Rand) {
 	}
 }
 
+// rng for the default models and the analyses: a fixed seed gives the same
+// model and the same advice for the same hand on every run
+func fixedRand(seed int64) *rand.Rand {
+	return rand.New(rand.NewSource(seed))
+}
+
 func Deal(deck Deck, count int) (Hand, Hand, Deck) {
 	p1 := make(Hand, 0, count)
 	p2 := make(Hand, 0, count)
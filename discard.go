@@ -1,8 +1,11 @@
 package cribbage
 
 import (
+	"context"
 	"fmt"
+	"sort"
 	"strings"
+	"sync"
 )
 
 type DiscardOption struct {
@@ -13,11 +16,12 @@ type DiscardOption struct {
 func (opt DiscardOption) ScoreRange(isDealer bool) (int, int) {
 	// best cribbage hand is 29 points
 	min, max := 29, 0
-	knownRemaining := difference(Hand(NewDeck()), append(opt.Keep, opt.Discard...))
+	keep := opt.Keep.Set()
+	knownRemaining := FullDeck &^ (keep | opt.Discard.Set())
 
-	for _, cut := range knownRemaining {
+	for cut := range knownRemaining.All() {
 		// Score is done during Show, crib is false
-		possiblePoints := opt.Keep.Score(cut, false)
+		possiblePoints := keep.Score(cut, false)
 		if isDealer {
 			possiblePoints += opt.Discard.HeuristicScore()
 		} else {
@@ -44,32 +48,44 @@ func DiscardAnalysis(options []DiscardOption, isDealer bool) {
 	} else {
 		msg += " (Opponent's Crib)"
 	}
+	evals, _ := EvaluateDiscards(context.Background(), options, isDealer)
+	sort.Sort(evals)
+
 	fmt.Println(msg)
 	fmt.Println(strings.Repeat("-", len(msg)))
-	for i, opt := range options {
+	for i, eval := range evals {
 		fmt.Printf("Option #%d\n", i+1)
-		ev := opt.ExpectedValue(isDealer)
-		min, max := opt.ScoreRange(isDealer)
-		fmt.Printf("Hand: %s\nCrib: %s\n", opt.Keep, opt.Discard)
-		fmt.Printf("Average points: %f\n", ev)
-		fmt.Printf("Score min, max = %d, %d\n\n", min, max)
+		fmt.Printf("Hand: %s\nCrib: %s\n", eval.Option.Keep, eval.Option.Discard)
+		fmt.Printf("Average points: %f\n", eval.EV)
+		fmt.Printf("Score min, max = %d, %d\n", eval.Min, eval.Max)
+		avg := eval.Average
+		fmt.Printf("Fifteens %.2f, Pairs %.2f, Runs %.2f, Flush %.2f, Nobs %.2f\n",
+			avg.Fifteens, avg.Pairs, avg.Runs, avg.Flush, avg.Nobs)
+
+		hand := eval.Option.HandDist()
+		fmt.Printf("Hand points (std dev %.2f, P(8+) %.1f%%, P(12+) %.1f%%)\n",
+			hand.StdDev(), 100*hand.AtLeast(8), 100*hand.AtLeast(12))
+		hand.Print()
+		crib := eval.Option.CribDist(isDealer)
+		fmt.Printf("Crib points: average %.2f, std dev %.2f, P(8+) %.1f%%\n",
+			crib.Mean(), crib.StdDev(), 100*crib.AtLeast(8))
+		crib.Print()
+		fmt.Println()
 	}
 	fmt.Println(strings.Repeat("-", len(msg)))
 }
 
 func OptimalDiscard(options []DiscardOption, isDealer bool) DiscardOption {
-	var bestOption DiscardOption = options[0]
-	var bestEV float64 = 0.0
+	evals, _ := EvaluateDiscards(context.Background(), options, isDealer)
+	best := evals[0]
 
 	// every Keep/Discard tuple and its average score
-	for _, option := range options {
-		ev := option.ExpectedValue(isDealer)
-		if ev > bestEV {
-			bestEV = ev
-			bestOption = option
+	for _, eval := range evals {
+		if eval.EV > best.EV {
+			best = eval
 		}
 	}
-	return bestOption
+	return best.Option
 }
 
 func PrintOptimal(options []DiscardOption, isDealer bool) {
@@ -90,26 +106,161 @@ func PrintOptimal(options []DiscardOption, isDealer bool) {
 func (opt DiscardOption) ExpectedValue(isDealer bool) float64 {
 	// Hand and Deck are []Card
 	var sumPoints int = 0
-	var minimumCrib int = 0
-	knownRemaining := difference(Hand(NewDeck()), append(opt.Keep, opt.Discard...))
+	keep := opt.Keep.Set()
+	knownRemaining := FullDeck &^ (keep | opt.Discard.Set())
 
 	// For every possible cut card, the Player would get that score during Show
-	for _, cut := range knownRemaining {
+	for cut := range knownRemaining.All() {
 		// Score is done during Show, crib is false
-		sumPoints += opt.Keep.Score(cut, false)
+		sumPoints += keep.Score(cut, false)
 	}
 	// Expected Points is the average of Show points from every possible cut card
 	// Anything in our Keep / Discard is not a cut, and we do not know the opponent's hand
-	count := float64(len(knownRemaining)) // 46
+	count := float64(knownRemaining.Len()) // 46
 	expectedShow := float64(sumPoints) / count
 
-	// if isDealer we can include known points from our discard
-	if isDealer {
-		// possible Score during Show of Crib (only 2 cards are known)
-		minimumCrib += opt.Discard.HeuristicScore()
-	} else {
-		minimumCrib -= opt.Discard.HeuristicScore()
+	// expected Crib points from the 2 known cards, by the trained weights
+	crib := opt.Discard.HeuristicScoreFloat()
+	if !isDealer {
+		crib = -crib
+	}
+
+	return expectedShow + crib
+}
+
+// Average points of each category over every cut card
+type CategoryAverages struct {
+	Fifteens float64
+	Pairs    float64
+	Runs     float64
+	Flush    float64
+	Nobs     float64
+}
+
+// Statistics of one DiscardOption over all 46 cut cards. EV matches
+// ExpectedValue (Show points and the trained crib weights), Min and Max match
+// ScoreRange (Show points and the guaranteed crib points).
+type DiscardEval struct {
+	Option   DiscardOption
+	EV       float64
+	Min      int
+	Max      int
+	Variance float64
+	Average  CategoryAverages // Show points of the Keep only
+}
+
+// Sortable by EV, best first: sort.Sort(evals)
+type DiscardEvals []DiscardEval
+
+func (e DiscardEvals) Len() int           { return len(e) }
+func (e DiscardEvals) Less(i, j int) bool { return e[i].EV > e[j].EV }
+func (e DiscardEvals) Swap(i, j int)      { e[i], e[j] = e[j], e[i] }
+
+// Evaluate every option in one sweep of the cut cards, one goroutine per option.
+// Results are in the order of options, or ctx.Err() if ctx is canceled first.
+func EvaluateDiscards(ctx context.Context, options []DiscardOption, isDealer bool) (DiscardEvals, error) {
+	evals := make(DiscardEvals, len(options))
+	var wg sync.WaitGroup
+	for i, opt := range options {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if ctx.Err() != nil {
+				return
+			}
+			evals[i] = opt.Evaluate(isDealer)
+		}()
 	}
+	wg.Wait()
 
-	return expectedShow + float64(minimumCrib)
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return evals, nil
+}
+
+func (opt DiscardOption) Evaluate(isDealer bool) DiscardEval {
+	keep := opt.Keep.Set()
+	knownRemaining := FullDeck &^ (keep | opt.Discard.Set())
+	crib := opt.Discard.HeuristicScore()
+	if !isDealer {
+		// effectively lost points by giving them to opponent
+		crib = -crib
+	}
+
+	eval := DiscardEval{Option: opt, Min: 29 + crib, Max: crib}
+	var sum, sumSquares float64
+	var total ScoreBreakdown
+	for cut := range knownRemaining.All() {
+		sb := keep.LookupBreakdown(cut, false)
+		points := sb.Total + crib
+		// the crib is a constant, so the Variance is of the Show points
+		sum += float64(sb.Total)
+		sumSquares += float64(sb.Total * sb.Total)
+		eval.Min = min(eval.Min, points)
+		eval.Max = max(eval.Max, points)
+
+		total.Fifteens += sb.Fifteens
+		total.Pairs += sb.Pairs
+		total.Runs += sb.Runs
+		total.Flush += sb.Flush
+		total.Nobs += sb.Nobs
+	}
+
+	count := float64(knownRemaining.Len())
+	show := sum / count
+	eval.Variance = max(0, sumSquares/count-show*show)
+	cribEV := opt.Discard.HeuristicScoreFloat()
+	if !isDealer {
+		cribEV = -cribEV
+	}
+	eval.EV = show + cribEV
+	eval.Average = CategoryAverages{
+		Fifteens: float64(total.Fifteens) / count,
+		Pairs:    float64(total.Pairs) / count,
+		Runs:     float64(total.Runs) / count,
+		Flush:    float64(total.Flush) / count,
+		Nobs:     float64(total.Nobs) / count,
+	}
+	return eval
+}
+
+// points of the kept Hand over every possible cut card
+func (opt DiscardOption) HandDist() Dist {
+	var counts []int
+	keep := opt.Keep.Set()
+	knownRemaining := FullDeck &^ (keep | opt.Discard.Set())
+	for cut := range knownRemaining.All() {
+		counts = addCount(counts, keep.Score(cut, false))
+	}
+	return distFromCounts(counts)
+}
+
+// points of the crib over every opponent discard and cut card, with the
+// opponent's throws weighted by DefaultThrowModel for their role
+func (opt DiscardOption) CribDist(isDealer bool) Dist {
+	return opt.CribDistWith(isDealer, DefaultThrowModel())
+}
+
+// CribDist with the throws weighted by m, or all equally likely if m is nil
+func (opt DiscardOption) CribDistWith(isDealer bool, m *ThrowModel) Dist {
+	var dist Dist
+	discard := opt.Discard.Set()
+	unseen := FullDeck &^ (opt.Keep.Set() | discard)
+	for first := range unseen.All() {
+		// second opponent card after the first in Index order
+		later := unseen &^ (CardSet(1)<<(first.Index()+1) - 1)
+		for second := range later.All() {
+			weight := 1.0
+			if m != nil {
+				// the opponent is the dealer when we are not
+				weight = m.ThrowWeight(first, second, !isDealer)
+			}
+			crib := discard.Add(first).Add(second)
+			for cut := range (unseen &^ crib).All() {
+				dist = addWeight(dist, crib.Score(cut, true), weight)
+			}
+		}
+	}
+	return dist.normalize()
 }
@@ -0,0 +1,71 @@
+package cribbage
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sort"
+	"testing"
+)
+
+func testDealt() Hand {
+	return Hand{
+		{Five, Clubs, Black}, {Five, Hearts, Red}, {Jack, Spades, Black},
+		{Four, Diamonds, Red}, {Six, Clubs, Black}, {King, Hearts, Red},
+	}
+}
+
+// One sweep gives the same numbers as ExpectedValue and ScoreRange
+func TestEvaluateDiscards_MatchesSeparatePasses(t *testing.T) {
+	options := testDealt().Split(4)
+	evals, err := EvaluateDiscards(context.Background(), options, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i, eval := range evals {
+		opt := options[i]
+		if math.Abs(eval.EV-opt.ExpectedValue(true)) > 1e-9 {
+			t.Fatalf("option %d: EV %f, want %f", i, eval.EV, opt.ExpectedValue(true))
+		}
+		min, max := opt.ScoreRange(true)
+		if eval.Min != min || eval.Max != max {
+			t.Fatalf("option %d: range %d-%d, want %d-%d", i, eval.Min, eval.Max, min, max)
+		}
+		if eval.Variance < 0 {
+			t.Fatalf("option %d: variance %f", i, eval.Variance)
+		}
+	}
+
+	sort.Sort(evals)
+	best := OptimalDiscard(options, true)
+	if !sort.IsSorted(evals) || evals[0].Option.Discard.String() != best.Discard.String() {
+		t.Fatalf("best discard %s, want %s", evals[0].Option.Discard, best.Discard)
+	}
+}
+
+func TestEvaluateDiscards_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := EvaluateDiscards(ctx, testDealt().Split(4), false); !errors.Is(err, context.Canceled) {
+		t.Fatalf("got %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestDiscardOption_Dists(t *testing.T) {
+	opt := testDealt().Split(4)[0]
+	hand := opt.HandDist()
+	avg := opt.Evaluate(true).Average
+	want := avg.Fifteens + avg.Pairs + avg.Runs + avg.Flush + avg.Nobs
+	if math.Abs(hand.Mean()-want) > 1e-9 {
+		t.Fatalf("hand mean %f, want %f", hand.Mean(), want)
+	}
+	if math.Abs(hand.AtLeast(0)-1) > 1e-9 || hand.AtLeast(30) != 0 {
+		t.Fatalf("P(0+) %f, P(30+) %f", hand.AtLeast(0), hand.AtLeast(30))
+	}
+
+	crib := opt.CribDist(true)
+	if math.Abs(crib.AtLeast(0)-1) > 1e-9 || crib.StdDev() <= 0 {
+		t.Fatalf("crib P(0+) %f, std dev %f", crib.AtLeast(0), crib.StdDev())
+	}
+}
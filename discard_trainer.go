@@ -0,0 +1,202 @@
+package cribbage
+
+// File contains the discard trainer: pick 2 cards to throw and compare with every DiscardOption
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// number of worst discards kept for review
+const worstMissCount = 10
+
+type DiscardMiss struct {
+	Dealt    Hand
+	IsDealer bool
+	Chosen   Hand
+	Best     Hand
+	Rank     int     // 1 is the best of the 15 options
+	Loss     float64 // expected points below OptimalDiscard
+}
+
+// Discard results saved across sessions
+type DiscardStats struct {
+	Problems  int
+	Optimal   int // problems where the best option was chosen
+	RankSum   int
+	TotalLoss float64
+	Worst     []DiscardMiss // largest Loss first
+}
+
+func LoadDiscardStats(path string) (*DiscardStats, error) {
+	stats := &DiscardStats{}
+	if _, err := loadJSON(path, stats); err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+func (s *DiscardStats) Save(path string) error {
+	return saveJSON(path, s)
+}
+
+func (s *DiscardStats) Record(miss DiscardMiss) {
+	s.Problems++
+	s.RankSum += miss.Rank
+	s.TotalLoss += miss.Loss
+	if miss.Rank == 1 {
+		s.Optimal++
+		return
+	}
+
+	s.Worst = append(s.Worst, miss)
+	sort.SliceStable(s.Worst, func(i, j int) bool { return s.Worst[i].Loss > s.Worst[j].Loss })
+	if len(s.Worst) > worstMissCount {
+		s.Worst = s.Worst[:worstMissCount]
+	}
+}
+
+// Rank the discard among all options of the dealt hand by ExpectedValue
+func GradeDiscard(dealt Hand, discard Hand, isDealer bool) (DiscardMiss, DiscardEvals) {
+	evals, _ := EvaluateDiscards(context.Background(), dealt.Split(4), isDealer)
+	sort.Stable(evals)
+
+	miss := DiscardMiss{Dealt: dealt, IsDealer: isDealer, Chosen: discard, Best: evals[0].Option.Discard}
+	for i, eval := range evals {
+		if eval.Option.Discard.Set() == discard.Set() {
+			miss.Rank = i + 1
+			miss.Loss = evals[0].EV - eval.EV
+			break
+		}
+	}
+	// equal EVs share the best rank
+	if miss.Loss == 0 {
+		miss.Rank = 1
+	}
+	return miss, evals
+}
+
+func (s *DiscardStats) Print() {
+	msg := "--- DISCARD RESULTS ---"
+	fmt.Println(msg)
+	if s.Problems > 0 {
+		n := float64(s.Problems)
+		fmt.Printf("%d hands, %.0f%% optimal\n", s.Problems, 100*float64(s.Optimal)/n)
+		fmt.Printf("Average rank %.2f of 15, average loss %.2f points\n", float64(s.RankSum)/n, s.TotalLoss/n)
+	}
+	fmt.Println(strings.Repeat("-", len(msg)))
+}
+
+func (s *DiscardStats) PrintWorst() {
+	msg := "--- WORST DISCARDS ---"
+	fmt.Println(msg)
+	for i, miss := range s.Worst {
+		role := "pone"
+		if miss.IsDealer {
+			role = "dealer"
+		}
+		fmt.Printf("%2d. %s as %s: threw %s, best %s (rank %d, -%.2f)\n",
+			i+1, miss.Dealt, role, miss.Chosen, miss.Best, miss.Rank, miss.Loss)
+	}
+	fmt.Println(strings.Repeat("-", len(msg)))
+}
+
+// 2 different card indices from 1 to n, such as "1 4"
+func ParseTwoIndices(input string, n int) (int, int, error) {
+	fields := strings.Fields(input)
+	if len(fields) != 2 {
+		return 0, 0, errors.New("enter 2 indices")
+	}
+	first, err1 := strconv.Atoi(fields[0])
+	second, err2 := strconv.Atoi(fields[1])
+	if err1 != nil || err2 != nil || first < 1 || second < 1 || first > n || second > n || first == second {
+		return 0, 0, fmt.Errorf("indices must be 2 different numbers from 1 to %d", n)
+	}
+	return first - 1, second - 1, nil
+}
+
+// Practice discards until the user quits, saving results to path after every hand
+func DiscardTrainer(path string) error {
+	stats, err := LoadDiscardStats(path)
+	if err != nil {
+		return err
+	}
+	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+	reader := bufio.NewReader(os.Stdin)
+	deck := NewDeck()
+
+	for {
+		deck.Shuffle(rng)
+		dealt := append(Hand{}, deck[:6]...)
+		isDealer := rng.Intn(2) == 0
+
+		ClearScreen()
+		if isDealer {
+			fmt.Println("You are the dealer: select 2 cards for your Crib.")
+		} else {
+			fmt.Println("You are the pone: select 2 cards for the opponent's Crib.")
+		}
+		fmt.Println("Say 'r' to review your worst discards, or 'q' to quit")
+		PromptIndices(dealt)
+
+		var first, second int
+		for {
+			fmt.Print("Select Cards with 2 indices separate by a space: ")
+			input, err := reader.ReadString('\n')
+			if err != nil {
+				return nil
+			}
+			input = strings.ToLower(strings.TrimSpace(input))
+			if input == "q" {
+				return nil
+			}
+			if input == "r" {
+				stats.PrintWorst()
+				PromptIndices(dealt)
+				continue
+			}
+			first, second, err = ParseTwoIndices(input, len(dealt))
+			if err == nil {
+				break
+			}
+			fmt.Printf("Invalid input: %v.\n", err)
+		}
+
+		discard := Hand{dealt[first], dealt[second]}
+		miss, evals := GradeDiscard(dealt, discard, isDealer)
+		fmt.Println()
+		for i, eval := range evals {
+			marker := " "
+			if eval.Option.Discard.Set() == discard.Set() {
+				marker = ">"
+			}
+			fmt.Printf("%s%2d. throw %s keep %s  %6.2f\n", marker, i+1, eval.Option.Discard, eval.Option.Keep, eval.EV)
+		}
+		if miss.Rank == 1 {
+			fmt.Println("\nOptimal discard!")
+		} else {
+			fmt.Printf("\nRank %d of %d: %.2f points below the optimal %s\n", miss.Rank, len(evals), miss.Loss, miss.Best)
+		}
+
+		stats.Record(miss)
+		if err := stats.Save(path); err != nil {
+			return err
+		}
+		fmt.Println()
+		stats.Print()
+
+		fmt.Print("\nPress Enter for the next hand, or 'q' to quit: ")
+		input, err := reader.ReadString('\n')
+		if err != nil || strings.ToLower(strings.TrimSpace(input)) == "q" {
+			return nil
+		}
+	}
+}
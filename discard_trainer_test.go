@@ -0,0 +1,46 @@
+package cribbage
+
+import (
+	"testing"
+)
+
+func TestGradeDiscard(t *testing.T) {
+	dealt := testDealt()
+	best := OptimalDiscard(dealt.Split(4), true)
+
+	miss, evals := GradeDiscard(dealt, best.Discard, true)
+	if miss.Rank != 1 || miss.Loss != 0 || len(evals) != 15 {
+		t.Fatalf("optimal discard graded %+v", miss)
+	}
+
+	miss, _ = GradeDiscard(dealt, Hand{dealt[0], dealt[1]}, true)
+	if miss.Rank == 1 || miss.Loss <= 0 {
+		t.Fatalf("throwing both 5s graded %+v", miss)
+	}
+}
+
+func TestDiscardStats_Worst(t *testing.T) {
+	stats := &DiscardStats{}
+	for i := range worstMissCount + 5 {
+		stats.Record(DiscardMiss{Rank: 2, Loss: float64(i)})
+	}
+	stats.Record(DiscardMiss{Rank: 1})
+
+	if stats.Problems != worstMissCount+6 || stats.Optimal != 1 || len(stats.Worst) != worstMissCount {
+		t.Fatalf("got %d problems, %d optimal, %d worst", stats.Problems, stats.Optimal, len(stats.Worst))
+	}
+	if stats.Worst[0].Loss != worstMissCount+4 {
+		t.Fatalf("worst miss lost %f", stats.Worst[0].Loss)
+	}
+}
+
+func TestParseTwoIndices(t *testing.T) {
+	if a, b, err := ParseTwoIndices("4 1", 6); err != nil || a != 3 || b != 0 {
+		t.Fatalf("got %d %d %v", a, b, err)
+	}
+	for _, input := range []string{"1", "1 1", "0 2", "2 7", "a b"} {
+		if _, _, err := ParseTwoIndices(input, 6); err == nil {
+			t.Fatalf("%q accepted", input)
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package cribbage
+
+// File contains point distributions (histograms) for hands, cribs and pegging
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// probability of scoring each number of points (index)
+type Dist []float64
+
+func (d Dist) Mean() float64 {
+	mean := 0.0
+	for points, p := range d {
+		mean += float64(points) * p
+	}
+	return mean
+}
+
+// distribution of the sum of two independent scores
+func (d Dist) Convolve(other Dist) Dist {
+	if len(d) == 0 || len(other) == 0 {
+		return Dist{}
+	}
+	sum := make(Dist, len(d)+len(other)-1)
+	for i, p := range d {
+		for j, q := range other {
+			sum[i+j] += p * q
+		}
+	}
+	return sum
+}
+
+// move every score by n points, scores below zero become zero
+func (d Dist) Shift(n int) Dist {
+	shifted := make(Dist, max(len(d)+n, 1))
+	for points, p := range d {
+		shifted[max(points+n, 0)] += p
+	}
+	return shifted
+}
+
+// normalized Dist from a count of each number of points
+func distFromCounts(counts []int) Dist {
+	total := 0
+	for _, n := range counts {
+		total += n
+	}
+	d := make(Dist, len(counts))
+	for points, n := range counts {
+		d[points] = float64(n) / float64(total)
+	}
+	return d
+}
+
+func addCount(counts []int, points int) []int {
+	for len(counts) <= points {
+		counts = append(counts, 0)
+	}
+	counts[points]++
+	return counts
+}
+
+// add weight to the points of an unnormalized Dist
+func addWeight(d Dist, points int, weight float64) Dist {
+	for len(d) <= points {
+		d = append(d, 0)
+	}
+	d[points] += weight
+	return d
+}
+
+// scale the weights of d to sum to 1
+func (d Dist) normalize() Dist {
+	total := 0.0
+	for _, w := range d {
+		total += w
+	}
+	if total == 0 {
+		return d
+	}
+	for points := range d {
+		d[points] /= total
+	}
+	return d
+}
+
+func (d Dist) StdDev() float64 {
+	mean := d.Mean()
+	variance := 0.0
+	for points, p := range d {
+		diff := float64(points) - mean
+		variance += p * diff * diff
+	}
+	return math.Sqrt(variance)
+}
+
+// probability of scoring n or more points
+func (d Dist) AtLeast(n int) float64 {
+	prob := 0.0
+	for points := max(n, 0); points < len(d); points++ {
+		prob += d[points]
+	}
+	return prob
+}
+
+// ASCII histogram, one row per possible score
+func (d Dist) Print() {
+	most := 0.0
+	for _, p := range d {
+		most = max(most, p)
+	}
+	const width = 30
+	for points, p := range d {
+		if p == 0 {
+			continue
+		}
+		bar := strings.Repeat("#", int(math.Round(p/most*width)))
+		fmt.Printf("%4d | %-*s %5.1f%%\n", points, width, bar, 100*p)
+	}
+}
@@ -0,0 +1,117 @@
+package cribbage
+
+// File contains exhaustive score frequencies of every hand and crib with every cut
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Number of (4 cards, cut) combinations scoring each number of points.
+// Hands and cribs hold the same cards and only differ by the crib flush rule.
+type ScoreFrequencies struct {
+	Hand       [30]int64
+	Crib       [30]int64
+	Checked    int64 // combinations compared with Hand.ScoreBreakdown
+	Mismatches int64
+}
+
+// Enumerate all 52 choose 4 hands with each of the 48 cuts (12,994,800 combinations).
+// Scores come from the lookup scorer; with verify every combination is also
+// scored by Hand.ScoreBreakdown and any difference is counted as a mismatch.
+func EnumerateScores(verify bool) ScoreFrequencies {
+	// one goroutine for each lowest card of the hand
+	parts := make([]ScoreFrequencies, 49)
+	var wg sync.WaitGroup
+	for a := range 49 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			f := &parts[a]
+			for b := a + 1; b < 52; b++ {
+				for c := b + 1; c < 52; c++ {
+					for d := c + 1; d < 52; d++ {
+						hand := CardSet(1)<<a | 1<<b | 1<<c | 1<<d
+						f.addHand(hand, verify)
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	var total ScoreFrequencies
+	for _, f := range parts {
+		for points := range total.Hand {
+			total.Hand[points] += f.Hand[points]
+			total.Crib[points] += f.Crib[points]
+		}
+		total.Checked += f.Checked
+		total.Mismatches += f.Mismatches
+	}
+	return total
+}
+
+func (f *ScoreFrequencies) addHand(hand CardSet, verify bool) {
+	var cards Hand
+	if verify {
+		cards = hand.Hand()
+	}
+	for cut := range (FullDeck &^ hand).All() {
+		show := hand.LookupBreakdown(cut, false)
+		crib := hand.LookupBreakdown(cut, true)
+		f.Hand[show.Total]++
+		f.Crib[crib.Total]++
+
+		if verify {
+			f.Checked++
+			if show != cards.ScoreBreakdown(cut, false) || crib != cards.ScoreBreakdown(cut, true) {
+				f.Mismatches++
+			}
+		}
+	}
+}
+
+func frequencyMean(counts [30]int64) float64 {
+	var sum, n int64
+	for points, count := range counts {
+		sum += int64(points) * count
+		n += count
+	}
+	return float64(sum) / float64(n)
+}
+
+func (f ScoreFrequencies) HandMean() float64 {
+	return frequencyMean(f.Hand)
+}
+
+func (f ScoreFrequencies) CribMean() float64 {
+	return frequencyMean(f.Crib)
+}
+
+func (f ScoreFrequencies) Print() {
+	var n int64
+	for _, count := range f.Hand {
+		n += count
+	}
+
+	msg := "--- SCORE FREQUENCIES (4 cards + cut) ---"
+	fmt.Println(msg)
+	fmt.Printf("%6s %12s %9s %12s %9s\n", "Points", "Hands", "P(hand)", "Cribs", "P(crib)")
+	for points := range f.Hand {
+		if f.Hand[points] == 0 && f.Crib[points] == 0 {
+			continue
+		}
+		fmt.Printf("%6d %12d %8.4f%% %12d %8.4f%%\n", points,
+			f.Hand[points], 100*float64(f.Hand[points])/float64(n),
+			f.Crib[points], 100*float64(f.Crib[points])/float64(n))
+	}
+	fmt.Printf("%6s %12d %9s %12d\n", "Total", n, "", n)
+	fmt.Printf("Expected hand: %.4f points\n", f.HandMean())
+	fmt.Printf("Expected crib: %.4f points (random cards, flush needs the cut)\n", f.CribMean())
+	if f.Checked > 0 {
+		fmt.Printf("Checked %d combinations against Hand.ScoreBreakdown: %d mismatches\n", f.Checked, f.Mismatches)
+	}
+	fmt.Println(strings.Repeat("-", len(msg)))
+}
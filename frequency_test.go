@@ -0,0 +1,36 @@
+package cribbage
+
+import (
+	"math"
+	"testing"
+)
+
+// Known counts for all 12,994,800 (hand, cut) combinations
+func TestEnumerateScores(t *testing.T) {
+	if testing.Short() {
+		t.Skip("exhaustive enumeration")
+	}
+	f := EnumerateScores(false)
+
+	tests := []struct {
+		points int
+		want   int64
+	}{
+		{0, 1009008},
+		{19, 0},
+		{28, 76},
+		{29, 4},
+	}
+	for _, tt := range tests {
+		if got := f.Hand[tt.points]; got != tt.want {
+			t.Fatalf("%d point hands: got %d, want %d", tt.points, got, tt.want)
+		}
+	}
+	if math.Abs(f.HandMean()-4.7692) > 5e-5 {
+		t.Fatalf("hand mean %f, want 4.7692", f.HandMean())
+	}
+	// a crib only scores a flush with the cut, other categories are the same
+	if f.Crib[29] != 4 || f.CribMean() >= f.HandMean() {
+		t.Fatalf("crib 29s %d, crib mean %f", f.Crib[29], f.CribMean())
+	}
+}
@@ -6,7 +6,10 @@ import (
 	"math/rand"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"runtime"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -35,19 +38,26 @@ func PromptIndices(hand Hand) {
 
 type Player interface {
 	// Player selects 4 Hand and 2 Crib cards, mutating state
-	Discard(isDealer bool) (Hand, Hand)
-	// Player places 1 card from PegHand, mutating state
-	PlayPegCard(state PegState) (Card, bool)
+	Discard(view PlayerView) (Hand, Hand)
+	// Player proposes 1 card from PegHand (or Go), the Game validates
+	// the play and removes the card with SetPegHand
+	PlayPegCard(view PlayerView) (Card, bool)
 	EmptyPegHand() bool
+	GetPegHand() Hand
+	SetPegHand(h Hand)
 	String() string
 	GetName() string
 	GetHand() Hand
 	SetHand(h Hand)
 	AddPoints(n int) int
 	GetScore() int
-	// select index from shuffled deck of 52
-	DrawCard() int
+	// clear points before a new game of a Match
+	ResetPoints()
+	// select index from the n cards spread face down
+	DrawCard(n int) int
 	CountHand(cut Card, isCrib bool) int
+	// points the Player claims the opponent missed in a count of claimed, 0 for none
+	CallMuggins(hand Hand, cut Card, isCrib bool, claimed int) int
 	// Human player acknowledges command line outputs
 	EnterToContinue()
 }
@@ -57,52 +67,104 @@ type Game struct {
 	Players [2]Player
 	Dealer  int
 	GameWon bool
+	Winner  int // index of Player, valid once GameWon
+	// cut for deal rule, the standard is low card deals
+	HighCardDeals bool
+	// rejected pegging plays before forfeit, 0 uses DefaultMaxIllegalPlays
+	MaxIllegalPlays int
+	// show the unseen-card tracker before each play of a HumanPlayer
+	Tracker bool
+	// the opponent takes the points a Player misses when counting
+	Muggins bool
+
+	// public cards of the current round, shown in each PlayerView
+	Cut      Card
+	CutShown bool
+	Played   [2]Hand // pegged cards of each Player
+	Piles    []Hand  // finished pegging piles
+	Discards [2]Hand // cards each Player put in the crib, seen only by that Player
+	Goes     [2][]PegGo
+
+	Round   int         // number of the current round, from 0
+	History GameHistory // every decision of this game, for Review
+
+	// saved profiles of HumanPlayers, may be nil
+	Profiles *ProfileStore
+}
+
+// Cut for deal: the lower card deals (or the higher with HighCardDeals).
+// Returns tie when both cards share a Rank and the deck must be reshuffled.
+func CutForDeal(cards [2]Card, highCardDeals bool) (dealer int, tie bool) {
+	if cards[0].Value() == cards[1].Value() {
+		return 0, true
+	}
+	lower := 0
+	if cards[1].Value() < cards[0].Value() {
+		lower = 1
+	}
+	if highCardDeals {
+		return 1 - lower, false
+	}
+	return lower, false
 }
 
 func (g *Game) ChooseDealer() {
 	seed := time.Now().UnixNano()
 	rng := rand.New(rand.NewSource(seed))
-	g.Deck.Shuffle(rng)
-
-	select0 := g.Players[0].DrawCard()
-	card0 := g.Deck[select0]
-	fmt.Printf("%s pulled %s\n", g.Players[0], card0)
-
-	select1 := g.Players[1].DrawCard()
-	if select0 == select1 {
-		select1 = (select1 + 1) % 52
+	rule := "lower"
+	if g.HighCardDeals {
+		rule = "higher"
 	}
-	card1 := g.Deck[select1]
-	fmt.Printf("%s pulled %s\n", g.Players[1], card1)
 
-	switch {
-	case card0.Value() == card1.Value():
-		fmt.Println("Cards have the same Rank! Try again...")
-		time.Sleep(1 * time.Second)
-		g.ChooseDealer()
-	case card0.Value() < card1.Value():
-		g.Dealer = 0
-		fmt.Printf("\n%s is the lower rank; ", card0)
-	case card0.Value() > card1.Value():
-		g.Dealer = 1
-		fmt.Printf("\n%s is the lower rank; ", card1)
-	default:
-		fmt.Println("TODO ChooseDealer")
-		g.Dealer = 0
+	title := "--- CUT FOR DEAL ---"
+	fmt.Println(title)
+	fmt.Printf("The %s card deals (Aces are low)\n", rule)
+	for {
+		g.Deck.Shuffle(rng)
+		// spread a copy so the Game deck keeps all 52 cards
+		spread := make(Deck, len(g.Deck))
+		copy(spread, g.Deck)
+		fmt.Printf("\nThe deck is shuffled and spread face down (%d cards)\n", len(spread))
+
+		var cut [2]Card
+		for i, player := range g.Players {
+			var choice int
+			cut[i], choice, spread = drawFromSpread(spread, player)
+			fmt.Printf("%s removes card #%d: %s\n", player, choice+1, cut[i])
+		}
+
+		dealer, tie := CutForDeal(cut, g.HighCardDeals)
+		if tie {
+			fmt.Printf("Both cards are %s! Reshuffle and cut again...\n", cut[0].Rank)
+			time.Sleep(1 * time.Second)
+			continue
+		}
+		g.Dealer = dealer
+		fmt.Printf("\n%s is the %s card; ", cut[dealer], rule)
+		break
 	}
 	fmt.Printf("%s will be the first Dealer\n", g.Players[g.Dealer])
+	fmt.Println(strings.Repeat("-", len(title)))
 	g.Players[0].EnterToContinue()
 	g.Players[1].EnterToContinue()
 }
 
+// The Card the Player draws, its index and the rest of the spread.
+// A bad index from the Player is clamped to the spread.
+func drawFromSpread(spread Deck, player Player) (Card, int, Deck) {
+	choice := min(max(player.DrawCard(len(spread)), 0), len(spread)-1)
+	card := spread[choice]
+	return card, choice, slices.Delete(spread, choice, choice+1)
+}
+
 func (g *Game) StartGame() {
-	roundNum := 0
+	g.Round = 0
 	for !g.GameWon {
 		ClearScreen()
-		fmt.Printf("--- Round #%d ---\n", roundNum+1)
+		fmt.Printf("--- Round #%d ---\n", g.Round+1)
 		g.PlayRound()
 		g.Dealer = 1 - g.Dealer
-		roundNum++
+		g.Round++
 	}
 }
 
@@ -112,11 +174,62 @@ func (game *Game) AddPoints(i, amount int) bool {
 	total := game.Players[i].AddPoints(amount)
 	if total >= 121 {
 		game.GameWon = true
+		game.Winner = i
 		return false
 	}
 	return true
 }
 
+// clear scores so the same Players can start another game
+func (game *Game) Reset() {
+	for _, player := range game.Players {
+		player.ResetPoints()
+	}
+	game.GameWon = false
+	game.Winner = 0
+	game.History = GameHistory{}
+}
+
+func (game *Game) RecordCount(seat int, hand Hand, isCrib bool, points int) {
+	game.History.Counts = append(game.History.Counts, HandCount{
+		Round:  game.Round,
+		Seat:   seat,
+		Hand:   append(Hand{}, hand...),
+		Cut:    game.Cut,
+		IsCrib: isCrib,
+		Points: points,
+	})
+}
+
+// Under Muggins the opponent of seat may take points missed in a count of claimed.
+// Returns true when the muggins points win the game.
+func (game *Game) CallMuggins(seat int, hand Hand, isCrib bool, claimed int) bool {
+	if !game.Muggins {
+		return false
+	}
+	// a computer without a Personality never misses points, nothing to call
+	if c, ok := game.Players[seat].(*ComputerPlayer); ok && c.Personality == nil {
+		return false
+	}
+	opp := 1 - seat
+	called := game.Players[opp].CallMuggins(hand, game.Cut, isCrib, claimed)
+	if called <= 0 {
+		return false
+	}
+	missed := hand.Score(game.Cut, isCrib) - claimed
+	if called > missed {
+		fmt.Printf("No muggins: %s missed %d points, not %d\n", game.Players[seat], missed, called)
+		return false
+	}
+	fmt.Printf("Muggins! %s takes %d points missed by %s\n", game.Players[opp], called, game.Players[seat])
+	game.AddPoints(opp, called)
+	if game.GameWon {
+		game.CelebrateWinner(opp)
+		return true
+	}
+	return false
+}
+
 // Print total points with some message/header
 func (g *Game) PrintPoints(msg string, previous0 int, previous1 int) {
 	previous := [2]int{previous0, previous1}
@@ -135,7 +248,6 @@ func (g *Game) PrintPoints(msg string, previous0 int, previous1 int) {
 func (game *Game) CelebrateWinner(winner int) {
 	Loser := game.Players[1-winner]
 	Winner := game.Players[winner]
-	diff := Winner.GetScore() - Loser.GetScore()
 
 	msg := fmt.Sprintf("\n--- %s won ---", Winner)
 	fmt.Printf("\n%s\n", msg)
@@ -145,9 +257,10 @@ func (game *Game) CelebrateWinner(winner int) {
 	}
 	fmt.Println(strings.Repeat("-", len(msg)))
 
-	if diff > 60 {
+	switch SkunkLevel(Loser.GetScore()) {
+	case 2:
 		fmt.Println("DOUBLE SKUNK")
-	} else if diff > 30 {
+	case 1:
 		fmt.Println("SKUNK")
 	}
 	fmt.Printf("Good Game %s!\n", Loser)
@@ -166,18 +279,31 @@ func (game *Game) PlayRound() {
 	crib := Hand{}
 	pone := 1 - game.Dealer
 	dealer := game.Dealer
+	game.Cut = Card{}
+	game.CutShown = false
+	game.Played = [2]Hand{}
+	game.Piles = nil
+	game.Discards = [2]Hand{}
+	game.Goes = [2][]PegGo{}
 
 	// Discard to form Crib
 	for i, player := range game.Players {
-		isDealer := i == dealer
-		discard, _ := player.Discard(isDealer)
+		view := game.View(i, PegState{})
+		discard, _ := player.Discard(view)
 		crib = append(crib, discard...)
-
+		game.Discards[i] = append(Hand{}, discard...)
+		game.History.Discards = append(game.History.Discards, DiscardDecision{
+			Round:   game.Round,
+			View:    view,
+			Discard: discard,
+		})
 	}
 
 	// Start Pegging round, show Cut card from top of shuffled deck
 	ClearScreen()
 	cut := remainingDeck[0]
+	game.Cut = cut
+	game.CutShown = true
 	fmt.Printf("Cut Card: %s\n", cut)
 	if cut.Rank == Jack {
 		fmt.Println("TWO FOR HIS HEELS")
@@ -199,6 +325,10 @@ func (game *Game) PlayRound() {
 	before0 := game.Players[0].GetScore()
 	before1 := game.Players[1].GetScore()
 	game.StartPegging()
+	game.History.Pegging = append(game.History.Pegging, [2]int{
+		game.Players[0].GetScore() - before0,
+		game.Players[1].GetScore() - before1,
+	})
 	if game.GameWon {
 		// CelebrateWinner inside StartPegging
 		return
@@ -219,32 +349,44 @@ func (game *Game) PlayRound() {
 	ponePlayer := game.Players[pone]
 	// TODO server calculates points instead
 	ponePoints := ponePlayer.CountHand(cut, false)
-	// Dealer Player acknowledges the points counted from Pone Computer
-	game.Players[dealer].EnterToContinue()
+	game.RecordCount(pone, ponePlayer.GetHand(), false, ponePoints)
 	game.AddPoints(pone, ponePoints)
 	if game.GameWon {
 		game.CelebrateWinner(pone)
 		return
 	}
+	if game.CallMuggins(pone, ponePlayer.GetHand(), false, ponePoints) {
+		return
+	}
+	// Dealer Player acknowledges the points counted from Pone Computer
+	game.Players[dealer].EnterToContinue()
 
 	// Score dealer's hand
 	dealPlayer := game.Players[dealer]
 	fmt.Printf("Cut Card: %s\n", cut)
 	dealerPoints := dealPlayer.CountHand(cut, false)
+	game.RecordCount(dealer, dealPlayer.GetHand(), false, dealerPoints)
 	game.AddPoints(dealer, dealerPoints)
 	if game.GameWon {
 		game.CelebrateWinner(dealer)
 		return
 	}
+	if game.CallMuggins(dealer, dealPlayer.GetHand(), false, dealerPoints) {
+		return
+	}
 
 	// Score dealer's crib, by overwriting their Hand and counting again
 	dealPlayer.SetHand(crib)
 	cribPoints := dealPlayer.CountHand(cut, true)
+	game.RecordCount(dealer, crib, true, cribPoints)
 	game.AddPoints(dealer, cribPoints)
 	if game.GameWon {
 		game.CelebrateWinner(dealer)
 		return
 	}
+	if game.CallMuggins(dealer, crib, true, cribPoints) {
+		return
+	}
 
 	fmt.Println()
 	game.PrintPoints("SUMMARY (SHOW)", before0, before1)
@@ -273,18 +415,21 @@ func NewComputerGame() *Game {
 	}
 }
 
-func NewPlayerGame() *Game {
-
-	fmt.Print("Please provide your name: ")
-	//fmt.Scanln(&name)
-	reader := bufio.NewReader(os.Stdin)
-	input, _ := reader.ReadString('\n')
-	name := strings.TrimSpace(input)
-
+// Game against the computer. The human Player picks a saved profile
+// from profiles, or types a name when profiles is nil.
+func NewPlayerGame(profiles *ProfileStore) *Game {
 	p1 := &HumanPlayer{
-		Name:   name,
 		Points: 0,
 	}
+	if profiles != nil {
+		p1.Profile = profiles.Choose()
+		p1.Name = p1.Profile.Name
+	} else {
+		fmt.Print("Please provide your name: ")
+		reader := bufio.NewReader(os.Stdin)
+		input, _ := reader.ReadString('\n')
+		p1.Name = strings.TrimSpace(input)
+	}
 
 	p2 := &ComputerPlayer{
 		Name:   "COM 1",
@@ -292,9 +437,10 @@ func NewPlayerGame() *Game {
 	}
 
 	return &Game{
-		Deck:    NewDeck(),
-		Players: [2]Player{p1, p2},
-		Dealer:  0,
+		Deck:     NewDeck(),
+		Players:  [2]Player{p1, p2},
+		Dealer:   0,
+		Profiles: profiles,
 	}
 }
 
@@ -334,14 +480,92 @@ func Start() {
 	fmt.Scanln(&input)
 	switch strings.ToLower(input) {
 	case "yes", "y":
-		game = NewPlayerGame()
+		game = NewPlayerGame(startProfiles())
+		fmt.Print("Show the unseen-card tracker during pegging? [y/n]: ")
+		input = ""
+		fmt.Scanln(&input)
+		switch strings.ToLower(input) {
+		case "yes", "y":
+			game.Tracker = true
+		}
+		fmt.Print("Computer opponent [expert/casual/novice] (Enter for expert): ")
+		input = ""
+		fmt.Scanln(&input)
+		computer := game.Players[1].(*ComputerPlayer)
+		if personality, ok := FindPersonality(input); ok {
+			fmt.Print("Seed of the computer's mistakes (Enter for a random seed): ")
+			input = ""
+			fmt.Scanln(&input)
+			seed, err := strconv.ParseInt(strings.TrimSpace(input), 10, 64)
+			if err != nil {
+				seed = time.Now().UnixNano()
+			}
+			personality.Seed = seed
+			// printed so the same mistakes can be replayed
+			fmt.Printf("%s opponent with seed %d\n", personality.Name, seed)
+			computer.Personality = &personality
+		} else {
+			fmt.Print("Computer pegging search time in milliseconds (Enter for no search): ")
+			input = ""
+			fmt.Scanln(&input)
+			if ms, err := strconv.Atoi(strings.TrimSpace(input)); err == nil && ms > 0 {
+				computer.Search = &ISMCTS{Budget: time.Duration(ms) * time.Millisecond}
+			}
+		}
+		fmt.Print("Play with muggins? [y/n]: ")
+		input = ""
+		fmt.Scanln(&input)
+		switch strings.ToLower(input) {
+		case "yes", "y":
+			game.Muggins = true
+			game.Players[0].(*HumanPlayer).Muggins = true
+		}
 	default:
 		game = NewComputerGame()
 	}
 
+	// a Match is a series of games, otherwise play a single game
+	matchTarget := 0
+	fmt.Print("Match points to play to (Enter for a single game): ")
+	input = ""
+	fmt.Scanln(&input)
+	if n, err := strconv.Atoi(strings.TrimSpace(input)); err == nil && n > 0 {
+		matchTarget = n
+	}
+
+	fmt.Print("Should the higher card deal on the cut for deal? [y/n]: ")
+	input = ""
+	fmt.Scanln(&input)
+	switch strings.ToLower(input) {
+	case "yes", "y":
+		game.HighCardDeals = true
+	}
+
 	fmt.Printf("Welcome %s and %s!\n", game.Players[0], game.Players[1])
+	if matchTarget > 0 {
+		fmt.Printf("A new match to %d points is beginning...\n\n", matchTarget)
+		time.Sleep(1 * time.Second)
+		NewMatch(game, matchTarget).Play()
+		return
+	}
 	fmt.Printf("A new game is beginning...\n\n")
 	time.Sleep(1 * time.Second)
 	game.ChooseDealer()
 	game.StartGame()
+	game.RecordProfiles()
+	game.OfferReview()
+}
+
+// Saved profiles from the data directory, nil if they cannot be loaded
+func startProfiles() *ProfileStore {
+	dir, err := DataDir()
+	if err != nil {
+		return nil
+	}
+	store, err := LoadProfiles(filepath.Join(dir, ProfilesFile))
+	if err != nil {
+		fmt.Printf("Could not load profiles: %v\n", err)
+		return nil
+	}
+	return store
 }
@@ -0,0 +1,59 @@
+package cribbage
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestCutForDeal(t *testing.T) {
+	tests := []struct {
+		name    string
+		cards   [2]Card
+		high    bool
+		want    int
+		wantTie bool
+	}{
+		{"Low card deals", [2]Card{{Rank: King}, {Rank: Ace}}, false, 1, false},
+		{"High card deals", [2]Card{{Rank: King}, {Rank: Ace}}, true, 0, false},
+		{"Jack beats Ten", [2]Card{{Rank: Ten}, {Rank: Jack}}, false, 0, false},
+		{"Tie on Rank", [2]Card{{Rank: Queen, Suit: Hearts}, {Rank: Queen, Suit: Spades}}, false, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dealer, tie := CutForDeal(tt.cards, tt.high)
+			if tie != tt.wantTie || (!tie && dealer != tt.want) {
+				t.Fatalf("got dealer %d tie %t, want dealer %d tie %t", dealer, tie, tt.want, tt.wantTie)
+			}
+		})
+	}
+}
+
+// draws outside the spread of n cards
+type badDrawPlayer struct {
+	*ComputerPlayer
+	choice int
+}
+
+func (p *badDrawPlayer) DrawCard(n int) int {
+	return p.choice
+}
+
+func TestDrawFromSpread_Clamps(t *testing.T) {
+	tests := []struct {
+		choice int
+		want   int
+	}{
+		{-5, 0},
+		{2, 2},
+		{99, 4},
+	}
+	for _, tt := range tests {
+		spread := append(Deck{}, NewDeck()[:5]...)
+		want := spread[tt.want]
+		card, index, rest := drawFromSpread(spread, &badDrawPlayer{&ComputerPlayer{}, tt.choice})
+		if card != want || index != tt.want || len(rest) != 4 || slices.Contains(rest, card) {
+			t.Fatalf("draw %d: got %s #%d, rest %v, want %s #%d", tt.choice, card, index, rest, want, tt.want)
+		}
+	}
+}
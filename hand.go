@@ -25,6 +25,19 @@ func (h Hand) String() string {
 	return strings.TrimRight(ret.String(), " ")
 }
 
+// Hand from cards separated by spaces, such as "5H 10S J♣"
+func ParseHand(s string) (Hand, error) {
+	h := Hand{}
+	for _, field := range strings.Fields(s) {
+		card, err := ParseCard(field)
+		if err != nil {
+			return nil, err
+		}
+		h = append(h, card)
+	}
+	return h, nil
+}
+
 func (h Hand) Choose(k int) []Hand {
 	var result []Hand
 	var helper func(start int, current Hand)
@@ -0,0 +1,172 @@
+package cribbage
+
+// File contains trained weights for the crib value of a two card discard
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"math"
+	"math/rand"
+)
+
+//go:generate go run ./cmd/trainweights
+
+// Weights fitted by cmd/trainweights with its default seed and samples
+//
+//go:embed cribweights.json
+var defaultCribWeightsJSON []byte
+
+// Features of a two card discard, by their names in CribWeights.Weights.
+// cribFeatureValues returns the values in this order.
+var CribFeatures = []string{
+	"bias", "fifteen", "pair", "gap1", "gap2", "gap3", "gap4", "suited", "five", "jack",
+}
+
+// Linear model of the expected crib points from the two cards one Player throws
+type CribWeights struct {
+	Samples int // simulated cribs used to fit the weights
+	Weights map[string]float64
+}
+
+// feature values of the discard, 1 or 0, in CribFeatures order
+func cribFeatureValues(discard Hand) []float64 {
+	a, b := discard[0], discard[1]
+	gap := int(a.Rank) - int(b.Rank)
+	if gap < 0 {
+		gap = -gap
+	}
+	values := make([]float64, len(CribFeatures))
+	set := func(i int, ok bool) {
+		if ok {
+			values[i] = 1
+		}
+	}
+	set(0, true)
+	set(1, a.ValueMax10()+b.ValueMax10() == 15)
+	set(2, gap == 0)
+	for g := 1; g <= 4; g++ {
+		set(2+g, gap == g)
+	}
+	set(7, a.Suit == b.Suit)
+	set(8, a.Rank == Five || b.Rank == Five)
+	set(9, a.Rank == Jack || b.Rank == Jack)
+	return values
+}
+
+// Expected crib points of the discard under the weights.
+// Float variant of HeuristicScore, which only counts guaranteed points;
+// ExpectedValue and OptimalDiscard use it with the DefaultCribWeights.
+func (discard Hand) HeuristicScoreWith(w *CribWeights) float64 {
+	score := 0.0
+	for i, value := range cribFeatureValues(discard) {
+		score += value * w.Weights[CribFeatures[i]]
+	}
+	return score
+}
+
+// HeuristicScoreWith the embedded DefaultCribWeights
+func (discard Hand) HeuristicScoreFloat() float64 {
+	return discard.HeuristicScoreWith(DefaultCribWeights())
+}
+
+var defaultCribWeights = func() *CribWeights {
+	w := &CribWeights{}
+	if err := json.Unmarshal(defaultCribWeightsJSON, w); err != nil {
+		panic(fmt.Sprintf("cribweights.json: %v", err))
+	}
+	return w
+}()
+
+func DefaultCribWeights() *CribWeights {
+	return defaultCribWeights
+}
+
+// Simulate cribs: a random discard, 2 random cards from the other Player and
+// a random cut. Fit the weights to the crib points by least squares.
+func TrainCribWeights(rng *rand.Rand, samples int) *CribWeights {
+	n := len(CribFeatures)
+	// normal equations: (X^T X) w = X^T y
+	xtx := make([][]float64, n)
+	for i := range xtx {
+		xtx[i] = make([]float64, n+1)
+	}
+	deck := NewDeck()
+	for range samples {
+		deck.Shuffle(rng)
+		crib := Hand(deck[:4])
+		points := float64(crib.Score(deck[4], true))
+		x := cribFeatureValues(crib[:2])
+		for i := range n {
+			for j := range n {
+				xtx[i][j] += x[i] * x[j]
+			}
+			xtx[i][n] += x[i] * points
+		}
+	}
+
+	solution := solveLinear(xtx)
+	w := &CribWeights{Samples: samples, Weights: make(map[string]float64, n)}
+	for i, name := range CribFeatures {
+		// rounded so the saved file is stable
+		w.Weights[name] = math.Round(solution[i]*1e4) / 1e4
+	}
+	return w
+}
+
+// Gauss-Jordan elimination with partial pivoting on an augmented n x (n+1) matrix.
+// A feature that never occurs gets weight 0.
+func solveLinear(m [][]float64) []float64 {
+	n := len(m)
+	for col := range n {
+		pivot := col
+		for row := col + 1; row < n; row++ {
+			if math.Abs(m[row][col]) > math.Abs(m[pivot][col]) {
+				pivot = row
+			}
+		}
+		if math.Abs(m[pivot][col]) < 1e-12 {
+			continue
+		}
+		m[col], m[pivot] = m[pivot], m[col]
+		for row := range n {
+			if row == col {
+				continue
+			}
+			f := m[row][col] / m[col][col]
+			for k := col; k <= n; k++ {
+				m[row][k] -= f * m[col][k]
+			}
+		}
+	}
+	x := make([]float64, n)
+	for i := range n {
+		if math.Abs(m[i][i]) >= 1e-12 {
+			x[i] = m[i][n] / m[i][i]
+		}
+	}
+	return x
+}
+
+func LoadCribWeights(path string) (*CribWeights, error) {
+	w := &CribWeights{}
+	found, err := loadJSON(path, w)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, &fs.PathError{Op: "open", Path: path, Err: fs.ErrNotExist}
+	}
+	for _, name := range CribFeatures {
+		if _, ok := w.Weights[name]; !ok {
+			return nil, errors.New("crib weights are missing " + name)
+		}
+	}
+	return w, nil
+}
+
+func (w *CribWeights) Save(path string) error {
+	return saveJSON(path, w)
+}
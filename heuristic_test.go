@@ -0,0 +1,72 @@
+package cribbage
+
+import (
+	"math"
+	"math/rand"
+	"path/filepath"
+	"testing"
+)
+
+func TestTrainCribWeights(t *testing.T) {
+	w := TrainCribWeights(rand.New(rand.NewSource(1)), 100000)
+	for _, name := range []string{"fifteen", "pair", "gap1", "five"} {
+		if w.Weights[name] <= 0 {
+			t.Fatalf("%s weight %.4f, want positive", name, w.Weights[name])
+		}
+	}
+	// a run needs the 2 cards close together
+	if w.Weights["gap1"] <= w.Weights["gap4"] {
+		t.Fatalf("gap1 %.4f, gap4 %.4f", w.Weights["gap1"], w.Weights["gap4"])
+	}
+}
+
+func TestHeuristicScoreFloat(t *testing.T) {
+	tests := []struct {
+		better, worse string
+	}{
+		{"5H 5S", "KD 9C"},
+		{"7H 8S", "2D 9C"},
+		{"5H JS", "KD 2C"},
+	}
+	for _, tt := range tests {
+		better, _ := ParseHand(tt.better)
+		worse, _ := ParseHand(tt.worse)
+		if better.HeuristicScoreFloat() <= worse.HeuristicScoreFloat() {
+			t.Errorf("%s %.2f <= %s %.2f", tt.better, better.HeuristicScoreFloat(), tt.worse, worse.HeuristicScoreFloat())
+		}
+	}
+}
+
+func TestCribWeights_SaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cribweights.json")
+	w := DefaultCribWeights()
+	if err := w.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := LoadCribWeights(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hand, _ := ParseHand("5H JH")
+	if got, want := hand.HeuristicScoreWith(loaded), hand.HeuristicScoreWith(w); got != want {
+		t.Fatalf("loaded score %.4f, want %.4f", got, want)
+	}
+
+	if err := (&CribWeights{Weights: map[string]float64{"bias": 4}}).Save(path); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadCribWeights(path); err == nil {
+		t.Fatal("loaded weights without every feature")
+	}
+}
+
+// The crib in ExpectedValue is the trained estimate, for the dealer and against the pone
+func TestExpectedValue_CribWeights(t *testing.T) {
+	dealt, _ := ParseHand("5H 5S 6D 7C KD QH")
+	for _, opt := range dealt.Split(4) {
+		got := opt.ExpectedValue(true) - opt.ExpectedValue(false)
+		if want := 2 * opt.Discard.HeuristicScoreFloat(); math.Abs(got-want) > 1e-9 {
+			t.Fatalf("%s: dealer minus pone %.4f, want %.4f", opt.Discard, got, want)
+		}
+	}
+}
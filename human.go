@@ -2,6 +2,7 @@ package cribbage
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
 	"math/rand/v2"
 	"os"
@@ -15,6 +16,9 @@ type HumanPlayer struct {
 	Hand    Hand
 	PegHand Hand
 	Points  int
+	Profile *Profile // lifetime statistics, may be nil
+	// the claimed count is scored, points missed are left to the opponent
+	Muggins bool
 }
 
 func (p *HumanPlayer) String() string {
@@ -38,21 +42,26 @@ func (p *HumanPlayer) AddPoints(n int) int {
 	return p.Points
 }
 
+func (p *HumanPlayer) ResetPoints() {
+	p.Points = 0
+}
+
 func (p *HumanPlayer) GetScore() int {
 	return p.Points
 }
 
-func (p *HumanPlayer) Discard(isDealer bool) (discard Hand, keep Hand) {
+func (p *HumanPlayer) Discard(view PlayerView) (discard Hand, keep Hand) {
 	// Player's Hand was created by SetHand and PegHand is CURRENTLY NIL
 	// Copy the Keep (4 cards) to PegHand so it can be emptied during Pegging
 	dealtHand := p.Hand // 6 cards
+	isDealer := view.IsDealer()
 
 	if isDealer {
 		fmt.Println("Select 2 cards to send to your Crib.")
 	} else {
 		fmt.Println("Select 2 cards to send to the opponent's Crib.")
 	}
-	fmt.Println("Say 'h' for a Hint on optimal selection")
+	fmt.Println("Say 'h' for a Hint on optimal selection, or 'p' to include pegging")
 	fmt.Println("Examples: '1 2', '4 1', '6 2'")
 	PromptIndices(dealtHand)
 
@@ -72,6 +81,10 @@ func (p *HumanPlayer) Discard(isDealer bool) (discard Hand, keep Hand) {
 			DiscardAnalysis(p.Hand.Split(4), isDealer)
 			PromptIndices(dealtHand)
 			continue
+		case "pegging", "p":
+			TotalAnalysis(p.Hand.Split(4), isDealer)
+			PromptIndices(dealtHand)
+			continue
 		case "optimal", "opt", "o", "win", "w":
 			PrintOptimal(p.Hand.Split(4), isDealer)
 			fmt.Println()
@@ -152,26 +165,32 @@ func (p *HumanPlayer) EmptyPegHand() bool {
 	return len(p.PegHand) == 0
 }
 
+func (p *HumanPlayer) GetPegHand() Hand {
+	return p.PegHand
+}
+
+func (p *HumanPlayer) SetPegHand(h Hand) {
+	p.PegHand = h
+}
+
 // return Card and bool for Passed/Go
 // if passed, Card is the empty/default struct
-func (p *HumanPlayer) PlayPegCard(state PegState) (Card, bool) {
+func (p *HumanPlayer) PlayPegCard(view PlayerView) (Card, bool) {
+	state := view.PegState
 	fmt.Println()
 
-	possible := false
 	// impossible if PegHand is empty or all cards Ranks are too high
+	legal := LegalPlays(state, p.PegHand)
+	possible := len(legal) > 0
 	for i, card := range p.PegHand {
-		value := card.ValueMax10()
-		if value <= 31-state.Sum {
-			possible = true
-		}
-		fmt.Printf("[%d] %s (value %d)\n", i+1, card, value)
+		fmt.Printf("[%d] %s (value %d)\n", i+1, card, card.ValueMax10())
 	}
 	if !possible {
 		// do not play GO automatically but inform the player
 		fmt.Println("(You must say Go)")
 	}
 
-	fmt.Println("\nSay 'g' to say Go, or 'h' for a Hint on optimal play")
+	fmt.Println("\nSay 'g' to say Go, 'h' for a Hint ranking every play, or 't' for the unseen cards")
 	var input string
 	for {
 		fmt.Print("Select an index to play that Card: ")
@@ -195,30 +214,15 @@ func (p *HumanPlayer) PlayPegCard(state PegState) (Card, bool) {
 				fmt.Printf("%s says Go.\n", p)
 			} else {
 				best, ok := OptimalPegging(state, p.PegHand)
-				var rmIdx int
 				if !ok {
 					// Play is possible but nothing is optimal
 					// automatically send first valid card
-					for i, card := range p.PegHand {
-						if card.ValueMax10() <= 31-state.Sum {
-							returnCard = card
-							rmIdx = i
-						}
-					}
+					returnCard = legal[0]
 					fmt.Printf("%s plays %s\n", p, returnCard)
 				} else {
 					returnCard = best
-					for i, card := range p.PegHand {
-						if card.String() == best.String() {
-							rmIdx = i
-							break
-						}
-					}
 					fmt.Printf("%s plays optimal %s\n", p, best)
-					//val, _ := ScorePeggingPlay(state, best)
-					//fmt.Printf(" (+%d)\n", val)
 				}
-				p.PegHand = slices.Delete(p.PegHand, rmIdx, rmIdx+1)
 			}
 			p.EnterToContinue()
 			return returnCard, sayGo
@@ -228,15 +232,12 @@ func (p *HumanPlayer) PlayPegCard(state PegState) (Card, bool) {
 				fmt.Println("You must say Go.")
 				continue
 			}
-
-			best, ok := OptimalPegging(state, p.PegHand)
-			if ok {
-				fmt.Printf("The best card to play is %s", best)
-				val, _ := ScorePeggingPlay(state, best)
-				fmt.Printf(" (+%d)\n", val)
-			} else {
-				fmt.Println("No optimal play detected.")
-			}
+			fmt.Println()
+			PeggingAnalysis(view, analysisSamples)
+			continue
+		case "tracker", "t":
+			fmt.Println()
+			view.PrintTracker()
 			continue
 		}
 
@@ -250,27 +251,26 @@ func (p *HumanPlayer) PlayPegCard(state PegState) (Card, bool) {
 		}
 
 		card := p.PegHand[i]
-		if card.ValueMax10() > 31-state.Sum {
-			fmt.Println("Invalid: pile would exceed 31.")
+		if err := CheckPegPlay(state, p.PegHand, card, false); err != nil {
+			fmt.Printf("Invalid: %v.\n", errors.Unwrap(err))
 			// repeat loop on PegHand selection or Say Go
 			continue
 		}
 
-		p.PegHand = slices.Delete(p.PegHand, i, i+1)
 		return card, false
 	}
 }
 
-func (p *HumanPlayer) DrawCard() int {
-	fmt.Print("Please select a Card from 1 to 52: ")
+func (p *HumanPlayer) DrawCard(n int) int {
+	fmt.Printf("Please select a Card from 1 to %d: ", n)
 	reader := bufio.NewReader(os.Stdin)
 	input, _ := reader.ReadString('\n')
 	input = strings.TrimSpace(input)
-	randomChoice := rand.IntN(52)
+	randomChoice := rand.IntN(n)
 
 	choice, err := strconv.Atoi(input)
-	if err != nil || choice < 1 || choice > 52 {
-		fmt.Printf("Invalid input: %d was randomly chosen for you\n", randomChoice)
+	if err != nil || choice < 1 || choice > n {
+		fmt.Printf("Invalid input: %d was randomly chosen for you\n", randomChoice+1)
 		return randomChoice
 	} else {
 		return choice - 1
@@ -278,111 +278,14 @@ func (p *HumanPlayer) DrawCard() int {
 }
 
 func (p *HumanPlayer) CountHand(cut Card, isCrib bool) int {
-	reader := bufio.NewReader(os.Stdin)
-	var input string
-	str_15 := " "
-	str_pair := " "
-	str_run := " "
-	str_flush := " "
-	str_nobs := " "
-
-	for input != "d" && input != "w" {
-		ClearScreen()
-		msg := " Count the number for each Point category "
-		if isCrib {
-			msg = " (Crib)" + msg
-		}
-		fmt.Println(msg)
-		fmt.Println(strings.Repeat("-", len(msg)))
-		fmt.Printf("Hand: %s  Cut: %s\n", p.Hand, cut)
-
-		fmt.Printf("[%s] Fifteens\n[%s] Pairs\n", str_15, str_pair)
-		fmt.Printf("[%s] Runs\n[%s] Flush\n[%s] Nobs\n\n", str_run, str_flush, str_nobs)
-
-		fmt.Print("Enter choice (1-5), or 'd' when done: ")
-		input, _ = reader.ReadString('\n')
-		input = strings.TrimSpace(input)
-
-		choice, err := strconv.Atoi(input)
-		if err != nil || choice < 1 || choice > 5 {
-			// cannot convert, or input was 'd' for Done
-			continue
-		}
+	claim := AskCount(p.Hand, cut, isCrib)
+	items := p.Hand.Itemize(cut, isCrib)
+	realPoints := items.Breakdown()
 
-		switch choice {
-		case 1:
-			fmt.Print("Number of fifteens [0-8] : ")
-			input, _ = reader.ReadString('\n')
-			num, err := strconv.Atoi(strings.TrimSpace(input))
-			if err != nil || num < 0 || num > 8 {
-				str_15 = " "
-			} else {
-				str_15 = fmt.Sprintf("%d", num)
-			}
-
-		case 2:
-			fmt.Print("Number of pairs [0-6] : ")
-			input, _ = reader.ReadString('\n')
-			num, err := strconv.Atoi(strings.TrimSpace(input))
-			if err != nil || num < 0 || num > 6 {
-				str_pair = " "
-			} else {
-				str_pair = fmt.Sprintf("%d", num)
-			}
-
-		case 3:
-			fmt.Print("Number of runs [0-4] : ")
-			input, _ = reader.ReadString('\n')
-			num, err := strconv.Atoi(strings.TrimSpace(input))
-			if err != nil || num < 0 || num > 4 {
-				str_run = " "
-			} else {
-				str_run = fmt.Sprintf("%d", num)
-			}
-
-		case 4:
-			fmt.Print("Flush [0, 4, 5] : ")
-			input, _ = reader.ReadString('\n')
-			num, err := strconv.Atoi(strings.TrimSpace(input))
-			if err != nil || (num != 0 && num != 4 && num != 5) {
-				str_flush = " "
-			} else {
-				str_flush = fmt.Sprintf("%d", num)
-			}
-
-		case 5:
-			fmt.Print("Nobs [0, 1] : ")
-			input, _ = reader.ReadString('\n')
-			num, err := strconv.Atoi(strings.TrimSpace(input))
-			if err != nil || (num != 0 && num != 1) {
-				str_nobs = " "
-			} else {
-				str_nobs = fmt.Sprintf("%d", num)
-			}
-
-		default:
-			continue
-		}
+	countedCorrect := CompareCount(claim, items)
+	if p.Profile != nil {
+		p.Profile.RecordCount(countedCorrect)
 	}
-
-	realPoints := p.Hand.ScoreBreakdown(cut, isCrib)
-	var userPoints ScoreBreakdown
-
-	// empty points categories will convert to (0, err)
-	fifteenCount, _ := strconv.Atoi(str_15)
-	pairCount, _ := strconv.Atoi(str_pair)
-	flushPoints, _ := strconv.Atoi(str_flush)
-	nobsPoints, _ := strconv.Atoi(str_nobs)
-	runCount, _ := strconv.Atoi(str_run)
-
-	userPoints.Fifteens = 2 * fifteenCount
-	userPoints.Pairs = 2 * pairCount
-	userPoints.Flush = flushPoints
-	userPoints.Nobs = nobsPoints
-	// save as count because we cannot distinguish Run(s) of 3/4 yet
-	userPoints.Runs = runCount
-
-	countedCorrect := CompareBreakDown(userPoints, realPoints)
 	if countedCorrect {
 		fmt.Println("You counted all points correctly! (NO MUGGINS)")
 	}
@@ -394,14 +297,30 @@ func (p *HumanPlayer) CountHand(cut Card, isCrib bool) int {
 		fmt.Printf("%s: %s", p.Name, p.Hand)
 	}
 	fmt.Printf(" (%d points)\n", realPoints.Total)
-	realPoints.Print()
+	items.Print()
 
 	fmt.Println()
 	p.EnterToContinue()
+	if p.Muggins {
+		// an over-count scores only the real points
+		return min(claim.Points.Total, realPoints.Total)
+	}
 	return realPoints.Total
 }
 
-// equality of two ScoreBreakdown structs, with console messages
+func (p *HumanPlayer) CallMuggins(hand Hand, cut Card, isCrib bool, claimed int) int {
+	fmt.Print("Muggins! Points missed in that count (Enter for none): ")
+	reader := bufio.NewReader(os.Stdin)
+	input, _ := reader.ReadString('\n')
+	points, err := strconv.Atoi(strings.TrimSpace(input))
+	if err != nil || points < 0 {
+		return 0
+	}
+	return points
+}
+
+// equality of two ScoreBreakdown structs, with console messages.
+// Runs are left to CompareCount, which knows their lengths.
 func CompareBreakDown(userPoints, realPoints ScoreBreakdown) bool {
 	countedCorrect := true
 
@@ -430,51 +349,6 @@ func CompareBreakDown(userPoints, realPoints ScoreBreakdown) bool {
 		fmt.Println(" (Jack with a suit matching the Cut Card)")
 	}
 
-	runPoints := realPoints.Runs
-	// field currently has just the NUMBER of runs input by
-	// the User, not actual points (UI does not distinguish run of 3/4/5)
-	switch userPoints.Runs {
-	case 0:
-		if runPoints > 0 {
-			countedCorrect = false
-			fmt.Println("Uncounted runs")
-		} else {
-			userPoints.Runs = 0
-		}
-	case 1:
-		// one run of 3 or 4 or 5
-		if runPoints != 3 && runPoints != 4 && runPoints != 5 {
-			countedCorrect = false
-			fmt.Println("Not 1 run")
-		} else {
-			userPoints.Runs = realPoints.Runs
-		}
-	case 2:
-		// two runs of 3 or 4
-		if runPoints != 6 && runPoints != 8 {
-			countedCorrect = false
-			fmt.Println("Not 2 runs")
-		} else {
-			userPoints.Runs = realPoints.Runs
-		}
-	case 3:
-		// three runs of 3
-		if runPoints != 9 {
-			countedCorrect = false
-			fmt.Println("Not 3 runs")
-		} else {
-			userPoints.Runs = 9
-		}
-	case 4:
-		// four runs of 3
-		if runPoints != 12 {
-			countedCorrect = false
-			fmt.Println("Not 4 runs")
-		} else {
-			userPoints.Runs = 12
-		}
-	}
-
 	return countedCorrect
 }
 
@@ -0,0 +1,127 @@
+package cribbage
+
+// File contains inference of the opponent's hand from their role and their play
+
+import (
+	"math"
+	"math/rand"
+)
+
+const (
+	// opponent deals sampled for a HandBelief
+	beliefSamples = 400
+	// a discard is chosen with probability proportional to
+	// exp(discardRationality * ExpectedValue), 0 would be a random discard
+	discardRationality = 1.5
+)
+
+// Posterior over the cards still in the opponent's hand.
+// Each of Hands is one possibility with its probability in Weights.
+type HandBelief struct {
+	Hands   []Hand
+	Weights []float64 // sums to 1
+}
+
+// rng seeded by the cards seen, so the same view always gives the same belief
+func (v PlayerView) Rand() *rand.Rand {
+	return rand.New(rand.NewSource(int64(v.Seen().Set())))
+}
+
+// Sample deals for the opponent from the unseen cards and weigh each by how
+// likely a reasonable player keeps those 4 cards in their role (dealer or pone).
+// Every Go of the opponent rules out holding a card that fit on the count.
+func InferOpponentHand(view PlayerView, samples int, rng *rand.Rand) *HandBelief {
+	opp := view.Opponent()
+	known := view.Played[opp]
+	held := view.Remaining[opp]
+	isDealer := view.Dealer == opp
+	pool := view.Unseen()
+	allowed := view.OpponentCards()
+
+	belief := &HandBelief{}
+	total := 0.0
+	for range samples {
+		rng.Shuffle(len(allowed), func(i, j int) { allowed[i], allowed[j] = allowed[j], allowed[i] })
+		remaining := append(Hand{}, allowed[:held]...)
+		kept := append(append(Hand{}, known...), remaining...)
+
+		// the 2 discards are any other unseen cards
+		rest := difference(pool, remaining)
+		if len(kept) != 4 || len(rest) < 2 {
+			belief.Hands = append(belief.Hands, remaining)
+			belief.Weights = append(belief.Weights, 1)
+			total++
+			continue
+		}
+		i := rng.Intn(len(rest))
+		j := rng.Intn(len(rest) - 1)
+		if j >= i {
+			j++
+		}
+		weight := keepLikelihood(kept, Hand{rest[i], rest[j]}, isDealer)
+
+		belief.Hands = append(belief.Hands, remaining)
+		belief.Weights = append(belief.Weights, weight)
+		total += weight
+	}
+	for i := range belief.Weights {
+		belief.Weights[i] /= total
+	}
+	return belief
+}
+
+// Unseen cards the opponent can still hold. Their unknown cards were held
+// at every Go, so none fit on that count. Every unseen card when the
+// Goes rule out too many (a forfeit or an illegal Go).
+func (v PlayerView) OpponentCards() Hand {
+	opp := v.Opponent()
+	pool := v.Unseen()
+	limit := 0
+	for _, g := range v.Goes[opp] {
+		limit = max(limit, 31-g.Count)
+	}
+	allowed := Hand{}
+	for _, card := range pool {
+		if card.ValueMax10() > limit {
+			allowed = append(allowed, card)
+		}
+	}
+	if len(allowed) < v.Remaining[opp] {
+		return pool
+	}
+	return allowed
+}
+
+// Probability of keeping kept from the 6 dealt cards (kept and discard),
+// with a softmax over the ExpectedValue of all 15 DiscardOptions
+func keepLikelihood(kept, discard Hand, isDealer bool) float64 {
+	dealt := append(append(Hand{}, kept...), discard...)
+	options := dealt.Split(4)
+	evs := make([]float64, len(options))
+	best := math.Inf(-1)
+	chosen := 0
+	for i, option := range options {
+		evs[i] = option.ExpectedValue(isDealer)
+		best = max(best, evs[i])
+		if option.Keep.Set() == kept.Set() {
+			chosen = i
+		}
+	}
+
+	sum := 0.0
+	for _, ev := range evs {
+		sum += math.Exp(discardRationality * (ev - best))
+	}
+	return math.Exp(discardRationality*(evs[chosen]-best)) / sum
+}
+
+// Probability the opponent still holds card
+func (b *HandBelief) CardProbability(card Card) float64 {
+	p := 0.0
+	for i, hand := range b.Hands {
+		if hand.Set().Has(card) {
+			p += b.Weights[i]
+		}
+	}
+	return p
+}
@@ -0,0 +1,59 @@
+package cribbage
+
+import "testing"
+
+func testBeliefView() PlayerView {
+	hand, _ := ParseHand("2C 3C 8D 9H")
+	discard, _ := ParseHand("QS KD")
+	cut, _ := ParseCard("JH")
+	return PlayerView{
+		Seat:      0,
+		Hand:      hand,
+		Discarded: discard,
+		Cut:       cut,
+		CutShown:  true,
+		Dealer:    1,
+		Remaining: [2]int{4, 4},
+	}
+}
+
+// Fives are worth keeping and the pone will not give one to the dealer's crib,
+// so the pone holds one more often than a random card
+func TestInferOpponentHand_KeepsFives(t *testing.T) {
+	view := testBeliefView()
+	view.Dealer = 0
+	belief := InferOpponentHand(view, 2000, view.Rand())
+
+	unseen := view.Unseen()
+	uniform := 4 / float64(len(unseen))
+	fives := 0.0
+	for _, card := range unseen {
+		if card.Rank == Five {
+			fives += belief.CardProbability(card) / 4
+		}
+	}
+	if fives < 1.1*uniform {
+		t.Fatalf("P(five) = %.3f, uniform %.3f", fives, uniform)
+	}
+}
+
+// A Go at 25 means no card of 6 or less is left
+func TestInferOpponentHand_Go(t *testing.T) {
+	view := testBeliefView()
+	view.Played[1], _ = ParseHand("10S 5D")
+	view.Remaining[1] = 2
+	view.Goes[1] = []PegGo{{Count: 25}}
+
+	belief := InferOpponentHand(view, 200, view.Rand())
+	total := 0.0
+	for _, card := range view.Unseen() {
+		p := belief.CardProbability(card)
+		if card.ValueMax10() <= 6 && p > 0 {
+			t.Fatalf("P(%s) = %.3f after a Go at 25", card, p)
+		}
+		total += p
+	}
+	if total < 1.999 || total > 2.001 {
+		t.Fatalf("expected cards held %.3f, want 2", total)
+	}
+}
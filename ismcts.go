@@ -0,0 +1,233 @@
+package cribbage
+
+// File contains an information set Monte Carlo tree search (ISMCTS) for Pegging plays
+
+import (
+	"math"
+	"math/rand"
+	"time"
+)
+
+// iterations of an ISMCTS without a Budget
+const DefaultSearchIterations = 2000
+
+// Pegging search over sampled opponent hands. Each iteration deals the
+// opponent's hidden cards from a HandBelief, walks one tree shared by every
+// deal (only the plays legal in that deal are considered), plays randomly to
+// the end of the hand and scores the net points of the rest of the play.
+type ISMCTS struct {
+	Iterations  int           // 0 uses DefaultSearchIterations when there is no Budget
+	Budget      time.Duration // time limit for one play, 0 for no limit
+	Exploration float64       // UCB constant in points, 0 uses 2
+	Rand        *rand.Rand    // nil uses the view's Rand
+}
+
+// one action of the search: a Rank played by the Player turn
+// (suits never score while pegging, so Cards of one Rank are the same play).
+// A Go decides who plays next only in some deals, so the Player is part of
+// the action and the points of a node always belong to one Player's choice.
+type searchNode struct {
+	turn     int
+	rank     Rank
+	children []*searchNode
+	visits   int
+	avail    int
+	total    float64 // sum of net points for the searching Player
+}
+
+func (n *searchNode) child(turn int, rank Rank) *searchNode {
+	for _, c := range n.children {
+		if c.turn == turn && c.rank == rank {
+			return c
+		}
+	}
+	return nil
+}
+
+// Pegging to the end of the hand by the rules of StartPegging
+type pegSim struct {
+	state  PegState
+	hands  [2]Hand
+	points [2]int
+	over   bool
+}
+
+// Skip Players who said Go until one has a legal Card.
+// Returns false, after the last card point, once every Card is played.
+func (s *pegSim) advance() bool {
+	for len(s.hands[0]) > 0 || len(s.hands[1]) > 0 {
+		turn := s.state.Turn
+		if s.state.Passed[turn] {
+			s.state.Turn = 1 - turn
+			continue
+		}
+		if len(LegalPlays(s.state, s.hands[turn])) > 0 {
+			return true
+		}
+		s.state.Passed[turn] = true
+		s.endTurn()
+	}
+	if !s.over {
+		s.over = true
+		if s.state.Sum != 0 {
+			s.points[s.state.LastPlayer]++
+		}
+	}
+	return false
+}
+
+func (s *pegSim) play(card Card) {
+	turn := s.state.Turn
+	points, _ := ScorePeggingPlay(s.state, card)
+	s.points[turn] += points
+	s.state.mustAddCard(card)
+	s.hands[turn] = difference(s.hands[turn], Hand{card})
+	s.endTurn()
+}
+
+// start a new pile when needed and pass the turn
+func (s *pegSim) endTurn() {
+	if s.state.ShouldReset() {
+		if s.state.Sum != 31 {
+			s.points[s.state.LastPlayer]++
+		}
+		s.state.Reset()
+	}
+	s.state.Turn = 1 - s.state.Turn
+}
+
+func cardOfRank(hand Hand, rank Rank) Card {
+	for _, card := range hand {
+		if card.Rank == rank {
+			return card
+		}
+	}
+	return Card{}
+}
+
+// Best play of the view by average net points, false without a legal Card
+func (s *ISMCTS) Choose(view PlayerView) (Card, bool) {
+	legal := LegalPlays(view.PegState, view.Hand)
+	if len(legal) == 0 {
+		return Card{}, false
+	}
+	if distinctRanks(legal) == 1 {
+		return legal[0], true
+	}
+
+	rng := s.Rand
+	if rng == nil {
+		rng = view.Rand()
+	}
+	iterations := s.Iterations
+	if iterations == 0 && s.Budget == 0 {
+		iterations = DefaultSearchIterations
+	}
+	exploration := s.Exploration
+	if exploration == 0 {
+		exploration = 2
+	}
+
+	belief := InferOpponentHand(view, beliefSamples, rng)
+	root := &searchNode{}
+	start := time.Now()
+	for i := 0; iterations == 0 || i < iterations; i++ {
+		if s.Budget > 0 && time.Since(start) > s.Budget {
+			break
+		}
+		s.iterate(root, view, belief.Sample(rng), exploration, rng)
+	}
+
+	if len(root.children) == 0 {
+		// the Budget ran out before the first iteration
+		return legal[0], true
+	}
+	best := root.children[0]
+	for _, c := range root.children {
+		if c.visits > best.visits {
+			best = c
+		}
+	}
+	return cardOfRank(legal, best.rank), true
+}
+
+// one determinization: select and expand in the tree, play out, back up
+func (s *ISMCTS) iterate(root *searchNode, view PlayerView, opponent Hand, exploration float64, rng *rand.Rand) {
+	me := view.Seat
+	sim := &pegSim{state: view.PegState}
+	sim.state.Turn = me
+	sim.state.CardPile = append(Hand{}, view.CardPile...)
+	sim.hands[me] = append(Hand{}, view.Hand...)
+	sim.hands[1-me] = opponent
+
+	path := []*searchNode{root}
+	node := root
+	expanded := false
+	for !expanded && sim.advance() {
+		turn := sim.state.Turn
+		hand := sim.hands[turn]
+		legal := LegalPlays(sim.state, hand)
+
+		var chosen *searchNode
+		bestScore := math.Inf(-1)
+		seen := [14]bool{}
+		for _, card := range legal {
+			if seen[card.Rank] {
+				continue
+			}
+			seen[card.Rank] = true
+			c := node.child(turn, card.Rank)
+			if c == nil {
+				if !expanded {
+					// expand the first untried play
+					c = &searchNode{turn: turn, rank: card.Rank}
+					node.children = append(node.children, c)
+					chosen, expanded = c, true
+				}
+				continue
+			}
+			c.avail++
+			if expanded {
+				continue
+			}
+			mean := c.total / float64(c.visits)
+			if turn != me {
+				mean = -mean
+			}
+			score := mean + exploration*math.Sqrt(math.Log(float64(c.avail))/float64(c.visits))
+			if score > bestScore {
+				chosen, bestScore = c, score
+			}
+		}
+		if expanded {
+			chosen.avail++
+		}
+		sim.play(cardOfRank(hand, chosen.rank))
+		node = chosen
+		path = append(path, node)
+	}
+
+	// random play to the end of the hand
+	for sim.advance() {
+		legal := LegalPlays(sim.state, sim.hands[sim.state.Turn])
+		sim.play(legal[rng.Intn(len(legal))])
+	}
+
+	net := float64(sim.points[me] - sim.points[1-me])
+	for _, n := range path {
+		n.visits++
+		n.total += net
+	}
+}
+
+// one hand of the belief, drawn by its weight
+func (b *HandBelief) Sample(rng *rand.Rand) Hand {
+	pick := rng.Float64()
+	for i, w := range b.Weights {
+		if pick < w {
+			return append(Hand{}, b.Hands[i]...)
+		}
+		pick -= w
+	}
+	return append(Hand{}, b.Hands[len(b.Hands)-1]...)
+}
@@ -0,0 +1,91 @@
+package cribbage
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// The search simulation scores a hand exactly like PegPlayout
+func TestPegSim_MatchesPegPlayout(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	deck := NewDeck()
+	greedy := [2]PegChooser{GreedyPegChooser, GreedyPegChooser}
+	for range 500 {
+		deck.Shuffle(rng)
+		hands := [2]Hand{append(Hand{}, deck[:4]...), append(Hand{}, deck[4:8]...)}
+		state := PegState{Turn: 0, CardPile: Hand{}}
+		want := PegPlayout(state, hands, greedy)
+
+		sim := &pegSim{state: state, hands: hands}
+		for sim.advance() {
+			hand := sim.hands[sim.state.Turn]
+			sim.play(GreedyPegChooser(sim.state, hand, LegalPlays(sim.state, hand)))
+		}
+		if sim.points != want {
+			t.Fatalf("%s vs %s: got %v, want %v", hands[0], hands[1], sim.points, want)
+		}
+	}
+}
+
+func TestISMCTS_Choose(t *testing.T) {
+	pile, _ := ParseHand("6C 5D")
+	hand, _ := ParseHand("4S 10H 8D")
+	view := PegPuzzle{Pile: pile, Hand: hand, OppCards: 3}.View()
+
+	search := &ISMCTS{Iterations: 500, Rand: rand.New(rand.NewSource(1))}
+	card, ok := search.Choose(view)
+	if !ok || card.Rank != Four {
+		t.Fatalf("got %s, want the 4 for a run and 15", card)
+	}
+
+	view.Hand = Hand{{Rank: King, Suit: Clubs}}
+	view.Sum = 25
+	if _, ok := search.Choose(view); ok {
+		t.Fatalf("played a King on 25")
+	}
+}
+
+// After my 5 on 20 the opponent replies with a 6 in one deal and says Go in
+// the other, where I play my 6. The two plays of a 6 are separate nodes.
+func TestISMCTS_GoInSomeDeals(t *testing.T) {
+	pile, _ := ParseHand("KC QC")
+	hand, _ := ParseHand("5S 6H")
+	view := PegPuzzle{Pile: pile, Hand: hand, OppCards: 2}.View()
+	replies, _ := ParseHand("6D KH")
+	goes, _ := ParseHand("KD KH")
+
+	search := &ISMCTS{}
+	root := &searchNode{}
+	rng := rand.New(rand.NewSource(1))
+	for range 200 {
+		opponent := replies
+		if rng.Intn(2) == 1 {
+			opponent = goes
+		}
+		search.iterate(root, view, append(Hand{}, opponent...), 2, rng)
+	}
+
+	five := root.child(view.Seat, Five)
+	if five == nil {
+		t.Fatalf("the 5 was never played")
+	}
+	mine, theirs := five.child(view.Seat, Six), five.child(view.Opponent(), Six)
+	if mine == nil || theirs == nil {
+		t.Fatalf("6 after the 5: mine %v, theirs %v", mine, theirs)
+	}
+	// every visit but the one that expanded the 5 went on to a 6
+	if mine.visits+theirs.visits != five.visits-1 {
+		t.Fatalf("6 visited %d + %d times, the 5 %d times", mine.visits, theirs.visits, five.visits)
+	}
+}
+
+// A whole game where one computer pegs by search
+func TestISMCTS_Game(t *testing.T) {
+	game := NewComputerGame()
+	game.Players[0].(*ComputerPlayer).Search = &ISMCTS{Iterations: 50}
+	game.ChooseDealer()
+	game.StartGame()
+	if !game.GameWon || game.Players[game.Winner].GetScore() < 121 {
+		t.Fatalf("game ended without a winner: %d to %d", game.Players[0].GetScore(), game.Players[1].GetScore())
+	}
+}
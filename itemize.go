@@ -0,0 +1,183 @@
+package cribbage
+
+// File contains the itemized score of a hand: every combination of cards that scores
+
+import (
+	"fmt"
+	"strings"
+)
+
+// One scoring combination, such as a fifteen or a pair
+type ScoreItem struct {
+	Category string // one of Categories
+	Cards    Hand
+	Points   int
+}
+
+type ScoreItems []ScoreItem
+
+// Every scoring combination of the hand with the cut, in call-out order:
+// fifteens, pairs, runs, flush and nobs. The Points add up to Hand.Score.
+func (h Hand) Itemize(cut Card, isCrib bool) ScoreItems {
+	all := append(h[:len(h):len(h)], cut)
+	items := ScoreItems{}
+
+	// same subset enumeration as Score_15
+	for mask := 3; mask < (1 << len(all)); mask++ {
+		sum := 0
+		var cards Hand
+		for i, c := range all {
+			if mask&(1<<i) != 0 {
+				sum += c.ValueMax10()
+				cards = append(cards, c)
+			}
+		}
+		if sum == 15 {
+			items = append(items, ScoreItem{"Fifteens", cards, 2})
+		}
+	}
+
+	for i := range all {
+		for j := i + 1; j < len(all); j++ {
+			if all[i].Rank == all[j].Rank {
+				items = append(items, ScoreItem{"Pairs", Hand{all[i], all[j]}, 2})
+			}
+		}
+	}
+
+	for _, run := range itemizeRuns(all) {
+		items = append(items, ScoreItem{"Runs", run, len(run)})
+	}
+
+	if points := Score_flush(h, cut, isCrib); points > 0 {
+		cards := append(Hand{}, h...)
+		if points == 5 {
+			cards = append(cards, cut)
+		}
+		items = append(items, ScoreItem{"Flush", cards, points})
+	}
+
+	for _, c := range h {
+		if c.Rank == Jack && c.Suit == cut.Suit {
+			items = append(items, ScoreItem{"Nobs", Hand{c}, 1})
+		}
+	}
+	return items
+}
+
+// Each run of the longest length, one card of each Rank per run
+// (a double run of 3 is 2 runs). At most one length can score in 5 cards.
+func itemizeRuns(cards Hand) []Hand {
+	byRank := make(map[Rank]Hand)
+	for _, c := range cards {
+		byRank[c.Rank] = append(byRank[c.Rank], c)
+	}
+
+	best, bestLen := Rank(0), 0
+	for r := Ace; r <= King; r++ {
+		n := 0
+		for byRank[r+Rank(n)] != nil {
+			n++
+		}
+		if n > bestLen {
+			best, bestLen = r, n
+		}
+	}
+	if bestLen < 3 {
+		return nil
+	}
+
+	runs := []Hand{{}}
+	for r := best; r < best+Rank(bestLen); r++ {
+		var next []Hand
+		for _, run := range runs {
+			for _, c := range byRank[r] {
+				next = append(next, append(run[:len(run):len(run)], c))
+			}
+		}
+		runs = next
+	}
+	return runs
+}
+
+func (items ScoreItems) Total() int {
+	total := 0
+	for _, item := range items {
+		total += item.Points
+	}
+	return total
+}
+
+// Category totals, equal to Hand.ScoreBreakdown
+func (items ScoreItems) Breakdown() ScoreBreakdown {
+	sb := ScoreBreakdown{}
+	for _, item := range items {
+		switch item.Category {
+		case "Fifteens":
+			sb.Fifteens += item.Points
+		case "Pairs":
+			sb.Pairs += item.Points
+		case "Runs":
+			sb.Runs += item.Points
+		case "Flush":
+			sb.Flush += item.Points
+		case "Nobs":
+			sb.Nobs += item.Points
+		}
+	}
+	sb.Total = items.Total()
+	return sb
+}
+
+var numberWords = []string{
+	"zero", "one", "two", "three", "four", "five", "six", "seven", "eight", "nine",
+	"ten", "eleven", "twelve", "thirteen", "fourteen", "fifteen", "sixteen",
+	"seventeen", "eighteen", "nineteen", "twenty", "twenty-one", "twenty-two",
+	"twenty-three", "twenty-four", "twenty-five", "twenty-six", "twenty-seven",
+	"twenty-eight", "twenty-nine",
+}
+
+func numberWord(n int) string {
+	if n >= 0 && n < len(numberWords) {
+		return numberWords[n]
+	}
+	return fmt.Sprint(n)
+}
+
+// Traditional count with a running total, one line per item:
+// "fifteen-two", "fifteen-four", "and a pair is six"...
+func (items ScoreItems) CallOut() []string {
+	lines := make([]string, 0, len(items))
+	total := 0
+	for _, item := range items {
+		total += item.Points
+		var call string
+		switch item.Category {
+		case "Fifteens":
+			call = "fifteen-" + numberWord(total)
+		case "Pairs":
+			call = "a pair is " + numberWord(total)
+		case "Runs":
+			call = fmt.Sprintf("a run of %s is %s", numberWord(item.Points), numberWord(total))
+		case "Flush":
+			call = fmt.Sprintf("a flush of %s is %s", numberWord(item.Points), numberWord(total))
+		case "Nobs":
+			call = "one for his nobs is " + numberWord(total)
+		}
+		if len(lines) > 0 && item.Category != "Fifteens" {
+			call = "and " + call
+		}
+		lines = append(lines, fmt.Sprintf("%-30s %s", call, item.Cards))
+	}
+	if len(lines) == 0 {
+		lines = append(lines, "nineteen (no points)")
+	}
+	return lines
+}
+
+func (items ScoreItems) Print() {
+	spacer := strings.Repeat(" ", 3)
+	for _, line := range items.CallOut() {
+		fmt.Println(spacer + line)
+	}
+}
@@ -0,0 +1,40 @@
+package cribbage
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestItemize_MatchesBreakdown(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	deck := NewDeck()
+	for range 20000 {
+		deck.Shuffle(rng)
+		hand, cut := Hand(deck[:4]), deck[4]
+		for _, isCrib := range []bool{false, true} {
+			got := hand.Itemize(cut, isCrib).Breakdown()
+			want := hand.ScoreBreakdown(cut, isCrib)
+			if got != want {
+				t.Fatalf("%s cut %s: got %+v, want %+v", hand, cut, got, want)
+			}
+		}
+	}
+}
+
+func TestItemize_CallOut(t *testing.T) {
+	hand, _ := ParseHand("4H 5S 6D 6C")
+	cut, _ := ParseCard("KS")
+	items := hand.Itemize(cut, false)
+
+	want := []string{"fifteen-two", "fifteen-four", "fifteen-six",
+		"and a pair is eight", "and a run of three is eleven", "and a run of three is fourteen"}
+	lines := items.CallOut()
+	if len(lines) != len(want) || items.Total() != 14 {
+		t.Fatalf("got %d items for %d points: %q", len(lines), items.Total(), lines)
+	}
+	for i, line := range lines {
+		if line[:len(want[i])] != want[i] {
+			t.Fatalf("line %d: got %q, want %q", i, line, want[i])
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package cribbage
+
+// File contains move validation for Pegging, enforced by the Game for every Player
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+var (
+	ErrOver31    = errors.New("pile would exceed 31")
+	ErrNotInHand = errors.New("card is not in the peg hand")
+	ErrFalseGo   = errors.New("cannot say Go while holding a playable card")
+)
+
+// Number of rejected plays in one turn before a Player forfeits the rest of the play
+const DefaultMaxIllegalPlays = 3
+
+type IllegalPlayError struct {
+	Card   Card
+	Passed bool // Player said Go
+	Sum    int  // pile count before the play
+	Err    error
+}
+
+func (e *IllegalPlayError) Error() string {
+	if e.Passed {
+		return fmt.Sprintf("illegal Go at count %d: %v", e.Sum, e.Err)
+	}
+	return fmt.Sprintf("illegal play %s at count %d: %v", e.Card, e.Sum, e.Err)
+}
+
+func (e *IllegalPlayError) Unwrap() error {
+	return e.Err
+}
+
+// every Card in hand that keeps the pile at or below 31
+func LegalPlays(state PegState, hand Hand) Hand {
+	legal := Hand{}
+	for _, card := range hand {
+		if card.ValueMax10() <= 31-state.Sum {
+			legal = append(legal, card)
+		}
+	}
+	return legal
+}
+
+// error if the Card (or Go) is not allowed for this hand and pile
+func CheckPegPlay(state PegState, hand Hand, card Card, passed bool) error {
+	legal := LegalPlays(state, hand)
+	if passed {
+		if len(legal) > 0 {
+			return &IllegalPlayError{Passed: true, Sum: state.Sum, Err: ErrFalseGo}
+		}
+		return nil
+	}
+	if !slices.Contains(hand, card) {
+		return &IllegalPlayError{Card: card, Sum: state.Sum, Err: ErrNotInHand}
+	}
+	if !slices.Contains(legal, card) {
+		return &IllegalPlayError{Card: card, Sum: state.Sum, Err: ErrOver31}
+	}
+	return nil
+}
+
+// Ask the Player whose turn it is for a Card, re-prompting after an illegal play.
+// A valid Card is removed from their PegHand. After MaxIllegalPlays rejections the
+// Player forfeits the rest of the play: their PegHand is emptied and ok is false.
+func (game *Game) RequestPegPlay(state PegState) (card Card, passed bool, ok bool) {
+	player := game.Players[state.Turn]
+	limit := game.MaxIllegalPlays
+	if limit <= 0 {
+		limit = DefaultMaxIllegalPlays
+	}
+
+	for range limit {
+		hand := player.GetPegHand()
+		view := game.View(state.Turn, state)
+		card, passed = player.PlayPegCard(view)
+		err := CheckPegPlay(state, hand, card, passed)
+		if err == nil {
+			if !passed {
+				player.SetPegHand(difference(hand, Hand{card}))
+				game.History.Plays = append(game.History.Plays, PegDecision{
+					Round: game.Round,
+					View:  view,
+					Card:  card,
+				})
+			}
+			return card, passed, true
+		}
+		fmt.Printf("%s: %v\n", player, err)
+	}
+
+	player.SetPegHand(Hand{})
+	return Card{}, true, false
+}
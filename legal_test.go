@@ -0,0 +1,71 @@
+package cribbage
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCheckPegPlay(t *testing.T) {
+	hand := Hand{{Rank: King, Suit: Spades}, {Rank: Two, Suit: Hearts}}
+	tests := []struct {
+		name   string
+		sum    int
+		card   Card
+		passed bool
+		want   error
+	}{
+		{"Legal", 21, hand[0], false, nil},
+		{"Over 31", 22, hand[0], false, ErrOver31},
+		{"Not in hand", 0, Card{Rank: Ace, Suit: Clubs}, false, ErrNotInHand},
+		{"False Go", 25, Card{}, true, ErrFalseGo},
+		{"Go", 30, Card{}, true, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckPegPlay(PegState{Sum: tt.sum}, hand, tt.card, tt.passed)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("got %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestAddCard_Over31(t *testing.T) {
+	s := PegState{Sum: 25, CardPile: makeStack(King, Queen, Five)}
+	if err := s.AddCard(Card{Rank: Seven}); !errors.Is(err, ErrOver31) {
+		t.Fatalf("got %v, want %v", err, ErrOver31)
+	}
+	if s.Sum != 25 || len(s.CardPile) != 3 {
+		t.Fatalf("state changed to sum %d pile %s", s.Sum, s.CardPile)
+	}
+}
+
+// always plays a King, even when the count does not allow it
+type kingPlayer struct {
+	*ComputerPlayer
+}
+
+func (p *kingPlayer) PlayPegCard(view PlayerView) (Card, bool) {
+	return Card{Rank: King, Suit: Spades}, false
+}
+
+func TestRequestPegPlay_Forfeit(t *testing.T) {
+	cheat := &kingPlayer{&ComputerPlayer{Name: "P1"}}
+	cheat.PegHand = Hand{{Rank: King, Suit: Spades}, {Rank: Ace, Suit: Clubs}}
+	game := &Game{Players: [2]Player{cheat, &ComputerPlayer{Name: "P2"}}}
+
+	if card, _, ok := game.RequestPegPlay(PegState{Sum: 10}); !ok || card.Rank != King {
+		t.Fatalf("legal King rejected: %s ok %t", card, ok)
+	}
+	if len(cheat.PegHand) != 1 {
+		t.Fatalf("played card not removed: %s", cheat.PegHand)
+	}
+	cheat.PegHand = Hand{{Rank: King, Suit: Spades}, {Rank: Ace, Suit: Clubs}}
+	if _, _, ok := game.RequestPegPlay(PegState{Sum: 25}); ok {
+		t.Fatalf("illegal King accepted")
+	}
+	if !cheat.EmptyPegHand() {
+		t.Fatalf("forfeit did not empty the peg hand: %s", cheat.PegHand)
+	}
+}
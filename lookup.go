@@ -0,0 +1,81 @@
+package cribbage
+
+// File contains the embedded lookup table of hand points that only depend on Ranks
+
+import (
+	_ "embed"
+)
+
+//go:generate go run ./cmd/genscores
+
+// Fifteens, Pairs and Runs of every multiset of 5 Ranks (4 hand cards and
+// the cut), 3 bytes per multiset in rankKey order. Suits only matter for
+// Flush and Nobs, which are checked directly. Written by BuildRankTable.
+//
+//go:embed rankscores.bin
+var rankTable []byte
+
+// number of multisets of 5 Ranks from 13: 17 choose 5
+const rankTableSize = 6188
+
+// binomial[n][k] = n choose k, for n < 17 and k <= 5
+var binomial = func() (b [17][6]int) {
+	for n := range 17 {
+		b[n][0] = 1
+		for k := 1; k <= min(n, 5); k++ {
+			b[n][k] = b[n-1][k-1] + b[n-1][k]
+		}
+	}
+	return
+}()
+
+// Canonical index of 5 Ranks (1-13) in any order. Sorted Ranks r0 <= ... <= r4
+// map to the distinct values r_i-1+i in 0-16, ranked in the combinatorial number system.
+func rankKey(ranks [5]int) int {
+	// insertion sort, 5 elements
+	for i := 1; i < 5; i++ {
+		for j := i; j > 0 && ranks[j] < ranks[j-1]; j-- {
+			ranks[j], ranks[j-1] = ranks[j-1], ranks[j]
+		}
+	}
+	key := 0
+	for i, r := range ranks {
+		key += binomial[r-1+i][i+1]
+	}
+	return key
+}
+
+// Fifteens + Pairs + Runs points of 5 Ranks
+func lookupRankPoints(ranks [5]int) int {
+	entry := rankTable[3*rankKey(ranks):]
+	return int(entry[0]) + int(entry[1]) + int(entry[2])
+}
+
+// Fifteens, Pairs and Runs of 5 Ranks, other fields are zero
+func lookupRankBreakdown(ranks [5]int) ScoreBreakdown {
+	entry := rankTable[3*rankKey(ranks):]
+	return ScoreBreakdown{Fifteens: int(entry[0]), Pairs: int(entry[1]), Runs: int(entry[2])}
+}
+
+// Score every multiset of 5 Ranks with Hand.ScoreBreakdown for the embedded table
+func BuildRankTable() []byte {
+	table := make([]byte, 3*rankTableSize)
+	var ranks [5]int
+	var fill func(i, low int)
+	fill = func(i, low int) {
+		if i == 5 {
+			// Suits do not matter for these categories
+			hand := Hand{{Rank: Rank(ranks[0])}, {Rank: Rank(ranks[1])}, {Rank: Rank(ranks[2])}, {Rank: Rank(ranks[3])}}
+			sb := hand.ScoreBreakdown(Card{Rank: Rank(ranks[4])}, false)
+			key := rankKey(ranks)
+			table[3*key], table[3*key+1], table[3*key+2] = byte(sb.Fifteens), byte(sb.Pairs), byte(sb.Runs)
+			return
+		}
+		for r := low; r <= int(King); r++ {
+			ranks[i] = r
+			fill(i+1, r)
+		}
+	}
+	fill(0, int(Ace))
+	return table
+}
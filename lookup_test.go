@@ -0,0 +1,71 @@
+package cribbage
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+)
+
+// The embedded table must match a fresh build (run go generate after scoring changes)
+func TestRankTable_UpToDate(t *testing.T) {
+	if !bytes.Equal(rankTable, BuildRankTable()) {
+		t.Fatalf("rankscores.bin is stale, run go generate")
+	}
+}
+
+func TestRankKey_Canonical(t *testing.T) {
+	seen := make(map[int]bool)
+	var fill func(ranks []int, low int)
+	fill = func(ranks []int, low int) {
+		if len(ranks) == 5 {
+			key := rankKey([5]int(ranks))
+			if key < 0 || key >= rankTableSize || seen[key] {
+				t.Fatalf("ranks %v have key %d", ranks, key)
+			}
+			seen[key] = true
+			return
+		}
+		for r := low; r <= int(King); r++ {
+			fill(append(ranks, r), r)
+		}
+	}
+	fill(nil, int(Ace))
+
+	if got := rankKey([5]int{13, 1, 5, 5, 11}); got != rankKey([5]int{1, 5, 5, 11, 13}) {
+		t.Fatalf("key depends on order")
+	}
+}
+
+// Table lookups agree with ScoreBreakdown for Hand and CardSet
+func TestLookupScore(t *testing.T) {
+	rng := rand.New(rand.NewSource(2))
+	deck := NewDeck()
+
+	for range 5000 {
+		deck.Shuffle(rng)
+		hand := Hand(deck[:4])
+		for _, cut := range deck[4:] {
+			for _, isCrib := range []bool{false, true} {
+				want := hand.ScoreBreakdown(cut, isCrib).Total
+				if got := hand.Score(cut, isCrib); got != want {
+					t.Fatalf("Hand %s cut %s crib %t: got %d, want %d", hand, cut, isCrib, got, want)
+				}
+				wantSB := hand.ScoreBreakdown(cut, isCrib)
+				if got := hand.Set().LookupBreakdown(cut, isCrib); got != wantSB {
+					t.Fatalf("CardSet %s cut %s crib %t: got %+v, want %+v", hand, cut, isCrib, got, wantSB)
+				}
+			}
+		}
+	}
+}
+
+func BenchmarkCuts_Lookup(b *testing.B) {
+	opt := benchOption()
+	keep := opt.Keep.Set()
+	remaining := FullDeck &^ (keep | opt.Discard.Set())
+	for b.Loop() {
+		for cut := range remaining.All() {
+			keep.Score(cut, false)
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package cribbage
+
+// File contains Match play: a series of games scored in match points
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Loser scores below these lines are skunked / double skunked
+const (
+	SkunkLine       = 91
+	DoubleSkunkLine = 61
+)
+
+// 0 for a normal loss, 1 for a skunk, 2 for a double skunk
+func SkunkLevel(loserScore int) int {
+	switch {
+	case loserScore < DoubleSkunkLine:
+		return 2
+	case loserScore < SkunkLine:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Match points awarded to the winner of one game
+type MatchScoring struct {
+	Win         int
+	Skunk       int
+	DoubleSkunk int
+}
+
+// standard tournament scoring
+var TournamentScoring = MatchScoring{Win: 2, Skunk: 3, DoubleSkunk: 4}
+
+func (ms MatchScoring) Points(loserScore int) int {
+	switch SkunkLevel(loserScore) {
+	case 2:
+		return ms.DoubleSkunk
+	case 1:
+		return ms.Skunk
+	default:
+		return ms.Win
+	}
+}
+
+type GameResult struct {
+	Winner      int    // index of Player
+	Scores      [2]int // final game points
+	MatchPoints int    // awarded to the winner
+}
+
+type Match struct {
+	Game    *Game
+	Target  int // match points needed to win the series
+	Scoring MatchScoring
+	Points  [2]int
+	Results []GameResult
+}
+
+func NewMatch(game *Game, target int) *Match {
+	return &Match{
+		Game:    game,
+		Target:  target,
+		Scoring: TournamentScoring,
+	}
+}
+
+func (m *Match) Over() bool {
+	return m.Points[0] >= m.Target || m.Points[1] >= m.Target
+}
+
+// index of Player with the most match points
+func (m *Match) Leader() int {
+	if m.Points[1] > m.Points[0] {
+		return 1
+	}
+	return 0
+}
+
+// Save the finished game and award match points.
+// The loser of a game deals first in the next one.
+func (m *Match) Record(game *Game) GameResult {
+	winner := game.Winner
+	loser := 1 - winner
+	result := GameResult{
+		Winner: winner,
+		Scores: [2]int{
+			game.Players[0].GetScore(),
+			game.Players[1].GetScore(),
+		},
+	}
+	result.MatchPoints = m.Scoring.Points(result.Scores[loser])
+
+	m.Points[winner] += result.MatchPoints
+	m.Results = append(m.Results, result)
+	game.Dealer = loser
+	return result
+}
+
+// Play games until a Player reaches the Target match points
+func (m *Match) Play() {
+	game := m.Game
+	for !m.Over() {
+		game.Reset()
+		if len(m.Results) == 0 {
+			game.ChooseDealer()
+		} else {
+			ClearScreen()
+			fmt.Printf("--- Game #%d ---\n", len(m.Results)+1)
+			fmt.Printf("%s lost the last game and will deal first\n", game.Players[game.Dealer])
+			game.Players[0].EnterToContinue()
+			game.Players[1].EnterToContinue()
+		}
+		game.StartGame()
+		game.RecordProfiles()
+		game.OfferReview()
+
+		m.Record(game)
+		fmt.Println()
+		m.PrintScoreboard()
+		game.Players[0].EnterToContinue()
+		game.Players[1].EnterToContinue()
+	}
+
+	winner := game.Players[m.Leader()]
+	fmt.Printf("\n%s wins the match %d to %d!\n", winner, m.Points[m.Leader()], m.Points[1-m.Leader()])
+}
+
+// Table of every finished game and the running match points
+func (m *Match) PrintScoreboard() {
+	names := [2]string{m.Game.Players[0].GetName(), m.Game.Players[1].GetName()}
+	width := max(len(names[0]), len(names[1]), 6)
+
+	msg := fmt.Sprintf("--- MATCH TO %d ---", m.Target)
+	fmt.Println(msg)
+	fmt.Printf("%-6s %*s %*s\n", "Game", width, names[0], width, names[1])
+	for i, result := range m.Results {
+		cells := [2]string{}
+		for p := range 2 {
+			cells[p] = fmt.Sprintf("%d", result.Scores[p])
+		}
+		label := ""
+		switch SkunkLevel(result.Scores[1-result.Winner]) {
+		case 2:
+			label = " (double skunk)"
+		case 1:
+			label = " (skunk)"
+		}
+		cells[result.Winner] += fmt.Sprintf("*+%d", result.MatchPoints)
+		fmt.Printf("%-6d %*s %*s%s\n", i+1, width, cells[0], width, cells[1], label)
+	}
+	fmt.Printf("%-6s %*d %*d\n", "Total", width, m.Points[0], width, m.Points[1])
+	fmt.Println(strings.Repeat("-", len(msg)))
+}
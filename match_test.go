@@ -0,0 +1,53 @@
+package cribbage
+
+import (
+	"testing"
+)
+
+func TestMatchScoring_Points(t *testing.T) {
+	tests := []struct {
+		name  string
+		loser int
+		want  int
+	}{
+		{"Win", 115, 2},
+		{"Win at skunk line", 91, 2},
+		{"Skunk", 90, 3},
+		{"Skunk at double line", 61, 3},
+		{"Double skunk", 60, 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := TournamentScoring.Points(tt.loser); got != tt.want {
+				t.Fatalf("got %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+// Loser of each game deals first in the next game
+func TestMatch_Record(t *testing.T) {
+	p1 := &ComputerPlayer{Name: "P1"}
+	p2 := &ComputerPlayer{Name: "P2"}
+	game := &Game{Players: [2]Player{p1, p2}}
+	match := NewMatch(game, 5)
+
+	p1.Points, p2.Points = 121, 85
+	game.GameWon, game.Winner = true, 0
+	match.Record(game)
+	if match.Points != [2]int{3, 0} || game.Dealer != 1 {
+		t.Fatalf("points %v dealer %d, want [3 0] dealer 1", match.Points, game.Dealer)
+	}
+
+	game.Reset()
+	p1.Points, p2.Points = 100, 121
+	game.GameWon, game.Winner = true, 1
+	match.Record(game)
+	if match.Points != [2]int{3, 2} || game.Dealer != 0 {
+		t.Fatalf("points %v dealer %d, want [3 2] dealer 0", match.Points, game.Dealer)
+	}
+	if match.Over() {
+		t.Fatalf("match over at %v, target %d", match.Points, match.Target)
+	}
+}
@@ -0,0 +1,292 @@
+package cribbage
+
+// File contains the pegging puzzle trainer, with puzzles shared through a JSON file
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"math/rand"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// opponent hands sampled to grade a puzzle
+const puzzleSamples = 500
+
+// A Pegging position where it is your turn to play
+type PegPuzzle struct {
+	Name     string `json:",omitempty"`
+	Pile     Hand   // current pile, in play order
+	Count    int    `json:",omitempty"` // pile count when earlier cards of the pile are not listed
+	Played   Hand   // cards of earlier piles this round
+	Hand     Hand   // your remaining cards
+	Cut      *Card  `json:",omitempty"`
+	OppCards int    // cards left in the opponent's hand
+	OppGo    int    `json:",omitempty"` // count at which the opponent said Go on this pile, 0 if they have not
+}
+
+func (p PegPuzzle) State() PegState {
+	state := PegState{Turn: 0, LastPlayer: 1, CardPile: append(Hand{}, p.Pile...)}
+	for _, card := range p.Pile {
+		state.Sum += card.ValueMax10()
+	}
+	state.Sum = max(state.Sum, p.Count)
+	state.Passed[1] = p.OppGo > 0
+	return state
+}
+
+// PlayerView of the puzzle from seat 0; the opponent is the dealer
+func (p PegPuzzle) View() PlayerView {
+	state := p.State()
+	view := PlayerView{
+		PegState:  state,
+		Seat:      0,
+		Hand:      append(Hand{}, p.Hand...),
+		Dealer:    1,
+		Remaining: [2]int{len(p.Hand), p.OppCards},
+	}
+	// pile and earlier cards are seen, who played them does not matter for the search
+	view.Played[1] = append(append(Hand{}, p.Played...), p.Pile...)
+	if p.Cut != nil {
+		view.Cut, view.CutShown = *p.Cut, true
+	}
+	if p.OppGo > 0 {
+		view.Goes[1] = []PegGo{{Count: p.OppGo}}
+	}
+	return view
+}
+
+// Every card must be distinct and it must be possible to play
+func (p PegPuzzle) Validate() error {
+	all := append(append(append(Hand{}, p.Pile...), p.Played...), p.Hand...)
+	if p.Cut != nil {
+		all = append(all, *p.Cut)
+	}
+	if all.Set().Len() != len(all) {
+		return errors.New("puzzle repeats a card")
+	}
+	if sum := p.State().Sum; sum > 31 {
+		return fmt.Errorf("pile count %d is over 31", sum)
+	}
+	if p.Count > 0 && p.Count < p.State().Sum {
+		return fmt.Errorf("count %d is below the pile's cards", p.Count)
+	}
+	if len(LegalPlays(p.State(), p.Hand)) == 0 {
+		return errors.New("no legal play in hand")
+	}
+	if p.OppCards < 0 || p.OppCards > 4 {
+		return fmt.Errorf("opponent cannot hold %d cards", p.OppCards)
+	}
+	if p.OppGo < 0 || p.OppGo > p.State().Sum {
+		return fmt.Errorf("opponent cannot say Go at %d on a count of %d", p.OppGo, p.State().Sum)
+	}
+	return nil
+}
+
+// Plays from a random deal: both Players play random legal cards until
+// it is your turn with at least 2 different Ranks to choose from
+func RandomPegPuzzle(rng *rand.Rand) PegPuzzle {
+	deck := NewDeck()
+	for {
+		deck.Shuffle(rng)
+		hands := [2]Hand{append(Hand{}, deck[:4]...), append(Hand{}, deck[4:8]...)}
+		state := PegState{Turn: rng.Intn(2), CardPile: Hand{}}
+		var played Hand
+		goCount := 0 // count of the opponent's Go on this pile
+		plays := rng.Intn(5)
+
+		for n := 0; ; n++ {
+			legal := LegalPlays(state, hands[state.Turn])
+			if state.Turn == 0 && n >= plays && distinctRanks(legal) >= 2 {
+				cut := deck[8]
+				return PegPuzzle{
+					Pile:     state.CardPile,
+					Played:   played,
+					Hand:     hands[0],
+					Cut:      &cut,
+					OppCards: len(hands[1]),
+					OppGo:    goCount,
+				}
+			}
+			if len(hands[0]) == 0 && len(hands[1]) == 0 {
+				break
+			}
+			if len(legal) == 0 {
+				if state.Turn == 1 && !state.Passed[1] {
+					goCount = state.Sum
+				}
+				state.Passed[state.Turn] = true
+			} else {
+				card := legal[rng.Intn(len(legal))]
+				state.mustAddCard(card)
+				hands[state.Turn] = difference(hands[state.Turn], Hand{card})
+			}
+			if state.ShouldReset() {
+				played = append(played, state.CardPile...)
+				state.Reset()
+				goCount = 0
+			}
+			state.Turn = 1 - state.Turn
+		}
+	}
+}
+
+func distinctRanks(h Hand) int {
+	seen := make(map[Rank]bool)
+	for _, card := range h {
+		seen[card.Rank] = true
+	}
+	return len(seen)
+}
+
+// Plays of the puzzle sorted by expected net points, best first
+func GradePegPuzzle(p PegPuzzle, rng *rand.Rand) []PlayValue {
+	values := EvaluatePegPlays(p.View(), puzzleSamples, rng)
+	sort.SliceStable(values, func(i, j int) bool { return values[i].Net > values[j].Net })
+	return values
+}
+
+// Puzzles from a JSON file, none if the file does not exist yet
+func LoadPegPuzzles(path string) ([]PegPuzzle, error) {
+	var puzzles []PegPuzzle
+	if _, err := loadJSON(path, &puzzles); err != nil {
+		return nil, err
+	}
+	for i, p := range puzzles {
+		if err := p.Validate(); err != nil {
+			return nil, fmt.Errorf("puzzle %d: %w", i+1, err)
+		}
+	}
+	return puzzles, nil
+}
+
+func SavePegPuzzles(path string, puzzles []PegPuzzle) error {
+	return saveJSON(path, puzzles)
+}
+
+func (p PegPuzzle) Print() {
+	state := p.State()
+	if p.Name != "" {
+		fmt.Printf("Puzzle: %s\n", p.Name)
+	}
+	if p.Cut != nil {
+		fmt.Printf("Cut Card: %s\n", *p.Cut)
+	}
+	if len(p.Played) > 0 {
+		fmt.Printf("Earlier piles: %s\n", p.Played)
+	}
+	fmt.Printf("Sum: %d\n%s [?]\n", state.Sum, p.Pile)
+	fmt.Printf("Opponent holds %d card(s)\n", p.OppCards)
+	if p.OppGo > 0 {
+		fmt.Printf("Opponent said Go at %d\n", p.OppGo)
+	}
+	PromptIndices(p.Hand)
+}
+
+// Present the puzzles of the file, then random ones, until the user quits.
+// Say 's' to save the current puzzle to the file.
+func PegPuzzleTrainer(path string) error {
+	puzzles, err := LoadPegPuzzles(path)
+	if err != nil {
+		return err
+	}
+	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+	reader := bufio.NewReader(os.Stdin)
+	var total float64
+	solved := 0
+	loaded := len(puzzles)
+
+	for n := 0; ; n++ {
+		var puzzle PegPuzzle
+		// puzzles of the file are already saved
+		saved := n < loaded
+		if saved {
+			puzzle = puzzles[n]
+		} else {
+			puzzle = RandomPegPuzzle(rng)
+		}
+		save := func() error {
+			if !saved {
+				puzzles = append(puzzles, puzzle)
+				saved = true
+			}
+			if err := SavePegPuzzles(path, puzzles); err != nil {
+				return err
+			}
+			fmt.Printf("Saved to %s\n", path)
+			return nil
+		}
+
+		ClearScreen()
+		fmt.Printf("--- PEGGING PUZZLE #%d ---\n", n+1)
+		puzzle.Print()
+
+		var choice Card
+		for {
+			fmt.Print("Select an index to play ('s' to save, 'q' to quit): ")
+			input, err := reader.ReadString('\n')
+			if err != nil {
+				return nil
+			}
+			input = strings.ToLower(strings.TrimSpace(input))
+			if input == "q" {
+				return nil
+			}
+			if input == "s" {
+				if err := save(); err != nil {
+					return err
+				}
+				continue
+			}
+
+			i, err := strconv.Atoi(input)
+			i--
+			if err != nil || i < 0 || i >= len(puzzle.Hand) {
+				fmt.Println("Invalid input.")
+				continue
+			}
+			if err := CheckPegPlay(puzzle.State(), puzzle.Hand, puzzle.Hand[i], false); err != nil {
+				fmt.Printf("Invalid: %v.\n", errors.Unwrap(err))
+				continue
+			}
+			choice = puzzle.Hand[i]
+			break
+		}
+
+		values := GradePegPuzzle(puzzle, rng)
+		fmt.Println()
+		var loss float64
+		for _, v := range values {
+			marker := " "
+			if v.Card == choice {
+				marker = ">"
+				loss = values[0].Net - v.Net
+			}
+			fmt.Printf("%s %s  +%d now, net %+.2f\n", marker, v.Card, v.Points, v.Net)
+		}
+		if loss < 0.005 {
+			solved++
+			fmt.Println("\nBest play!")
+		} else {
+			fmt.Printf("\n%.2f points below the best play %s\n", loss, values[0].Card)
+		}
+		total += loss
+		fmt.Printf("Solved %d of %d, average loss %.2f\n", solved, n+1, total/float64(n+1))
+
+		fmt.Print("\nPress Enter for the next puzzle, 's' to save this one, or 'q' to quit: ")
+		input, err := reader.ReadString('\n')
+		input = strings.ToLower(strings.TrimSpace(input))
+		if input == "s" {
+			if err := save(); err != nil {
+				return err
+			}
+		}
+		if err != nil || input == "q" {
+			return nil
+		}
+	}
+}
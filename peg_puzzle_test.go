@@ -0,0 +1,89 @@
+package cribbage
+
+import (
+	"math/rand"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseCard(t *testing.T) {
+	tests := []struct {
+		text string
+		want Card
+	}{
+		{"5H", Card{Five, Hearts, Red}},
+		{"10s", Card{Ten, Spades, Black}},
+		{"TS", Card{Ten, Spades, Black}},
+		{"Q♦", Card{Queen, Diamonds, Red}},
+		{"A♣", Card{Ace, Clubs, Black}},
+	}
+	for _, tt := range tests {
+		if got, err := ParseCard(tt.text); err != nil || got != tt.want {
+			t.Fatalf("ParseCard(%q) = %s, %v", tt.text, got, err)
+		}
+	}
+	for _, text := range []string{"", "5", "1H", "11S", "KX"} {
+		if _, err := ParseCard(text); err == nil {
+			t.Fatalf("ParseCard(%q) accepted", text)
+		}
+	}
+}
+
+func TestPegPuzzle_SaveLoad(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	puzzles := []PegPuzzle{RandomPegPuzzle(rng), RandomPegPuzzle(rng)}
+	for _, p := range puzzles {
+		if err := p.Validate(); err != nil {
+			t.Fatalf("random puzzle %+v: %v", p, err)
+		}
+	}
+
+	path := filepath.Join(t.TempDir(), "puzzles.json")
+	if err := SavePegPuzzles(path, puzzles); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := LoadPegPuzzles(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(loaded) != 2 || loaded[1].Hand.String() != puzzles[1].Hand.String() || *loaded[0].Cut != *puzzles[0].Cut {
+		t.Fatalf("loaded %+v", loaded)
+	}
+}
+
+// On 6 5, a 4 makes a run of 3 for 15; the 10 leaves 21 for an easy 31
+func TestGradePegPuzzle(t *testing.T) {
+	pile, _ := ParseHand("6C 5D")
+	hand, _ := ParseHand("4S 10H")
+	puzzle := PegPuzzle{Pile: pile, Hand: hand, OppCards: 3}
+
+	values := GradePegPuzzle(puzzle, rand.New(rand.NewSource(1)))
+	if len(values) != 2 || values[0].Card.Rank != Four || values[0].Points != 5 {
+		t.Fatalf("got %+v", values)
+	}
+}
+
+// After the opponent's Go only you can play on the pile
+func TestGradePegPuzzle_OppGo(t *testing.T) {
+	pile, _ := ParseHand("KS QH")
+	hand, _ := ParseHand("5D 4C")
+	p := PegPuzzle{Pile: pile, Hand: hand, OppCards: 2}
+
+	replies := func(p PegPuzzle) float64 {
+		total := 0.0
+		for _, v := range GradePegPuzzle(p, rand.New(rand.NewSource(1))) {
+			total += v.Reply + float64(v.WorstReply)
+		}
+		return total
+	}
+	if replies(p) == 0 {
+		t.Fatalf("no replies on a count of 20 before a Go")
+	}
+	p.OppGo = 20
+	if err := p.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	if got := replies(p); got != 0 {
+		t.Fatalf("replies worth %.2f after the opponent's Go", got)
+	}
+}
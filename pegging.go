@@ -16,6 +16,11 @@ type PegState struct {
 	PileNum    int     // 1-3 piles of <=31 per Pegging round
 }
 
+// A Player said Go: no card in their hand fit on the count
+type PegGo struct {
+	Count int // pile count when they said Go
+}
+
 // Players try to place all of their cards on the pile
 // Once the score goes above 31, start a new pile
 func (game *Game) StartPegging() {
@@ -43,15 +48,24 @@ func (game *Game) StartPegging() {
 			fmt.Printf("%s ", c)
 		}
 		fmt.Println("[?]")
+		if _, human := players[state.Turn].(*HumanPlayer); human && game.Tracker {
+			game.View(state.Turn, state).PrintTracker()
+		}
 
-		card, passed := players[state.Turn].PlayPegCard(state)
-		if passed {
+		card, passed, ok := game.RequestPegPlay(state)
+		if !ok {
+			fmt.Printf("%s forfeits the rest of the play", players[state.Turn])
+			state.Passed[state.Turn] = true
+		} else if passed {
 			fmt.Printf("%s says GO", players[state.Turn])
 			state.Passed[state.Turn] = true
+			game.Goes[state.Turn] = append(game.Goes[state.Turn], PegGo{Count: state.Sum})
 		} else {
 			fmt.Printf("%s plays %s", players[state.Turn], card)
 			points, comment := ScorePeggingPlay(state, card)
-			state.AddCard(card)
+			// RequestPegPlay only returns legal cards
+			state.mustAddCard(card)
+			game.Played[state.Turn] = append(game.Played[state.Turn], card)
 			if points > 0 {
 				fmt.Print(comment)
 				game.AddPoints(state.Turn, points)
@@ -74,6 +88,7 @@ func (game *Game) StartPegging() {
 				}
 			}
 			fmt.Printf("\n\n")
+			game.Piles = append(game.Piles, state.CardPile)
 			state.Reset()
 			if !EmptyHands(players) {
 				game.Players[0].EnterToContinue()
@@ -178,7 +193,11 @@ func ScorePegRuns(stack Hand) int {
 // with the string to append in the terminal output
 func ScorePeggingPlay(s PegState, c Card) (points int, msg string) {
 	// state was not updated with Card in the caller yet
-	s.AddCard(c)
+	// (StartPegging rejects cards over 31 before scoring them)
+	if err := s.AddCard(c); err != nil {
+		// a Card that does not fit on the pile scores nothing
+		return 0, ""
+	}
 
 	points = 0
 
@@ -210,13 +229,22 @@ func ScorePeggingPlay(s PegState, c Card) (points int, msg string) {
 	return
 }
 
-func (s *PegState) AddCard(c Card) {
+// place a Card on the pile, a Card that would exceed 31 is rejected
+func (s *PegState) AddCard(c Card) error {
 	if s.Sum+c.ValueMax10() > 31 {
-		fmt.Printf("TODO over 31 in AddCard")
+		return &IllegalPlayError{Card: c, Sum: s.Sum, Err: ErrOver31}
 	}
 	s.Sum += c.ValueMax10()
 	s.CardPile = append(s.CardPile, c)
 	s.LastPlayer = s.Turn
+	return nil
+}
+
+// AddCard of a Card already checked to be legal, an illegal Card is a bug
+func (s *PegState) mustAddCard(c Card) {
+	if err := s.AddCard(c); err != nil {
+		panic(err)
+	}
 }
 
 func (s *PegState) ShouldReset() bool {
@@ -25,11 +25,13 @@ func TestPegging_15_31(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			// state before the last card of the stack is played
+			last := tt.stack[len(tt.stack)-1]
 			s := PegState{
-				Sum:      tt.sum,
-				CardPile: tt.stack,
+				Sum:      tt.sum - last.ValueMax10(),
+				CardPile: tt.stack[:len(tt.stack)-1],
 			}
-			if got, _ := ScorePeggingPlay(s, tt.stack[len(tt.stack)-1]); got != tt.want {
+			if got, _ := ScorePeggingPlay(s, last); got != tt.want {
 				t.Fatalf("got %d, want %d", got, tt.want)
 			}
 		})
@@ -102,3 +104,18 @@ func TestPegging_GoGo(t *testing.T) {
 		t.Fatalf("got %d, want 1", p.Points)
 	}
 }
+
+func TestPegState_IllegalCard(t *testing.T) {
+	king := Card{Rank: King, Suit: Spades}
+	state := PegState{Sum: 25, CardPile: Hand{}}
+	if points, msg := ScorePeggingPlay(state, king); points != 0 || msg != "" {
+		t.Fatalf("King on 25 scored %d %q", points, msg)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("King on 25 reached the pile: %s", state.CardPile)
+		}
+	}()
+	state.mustAddCard(king)
+}
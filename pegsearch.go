@@ -0,0 +1,188 @@
+package cribbage
+
+// File contains a full lookahead search of the Pegging play, following the rules of StartPegging
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// Net points (me minus opponent) for the rest of the play when both
+// hands are known and both Players play their best. state.Turn acts next.
+func PegMinimax(state PegState, hands [2]Hand, me int) int {
+	if len(hands[0]) == 0 && len(hands[1]) == 0 {
+		// last card of the final pile
+		if state.Sum != 0 {
+			return pegSign(state.LastPlayer, me)
+		}
+		return 0
+	}
+
+	turn := state.Turn
+	if state.Passed[turn] {
+		state.Turn = 1 - turn
+		return PegMinimax(state, hands, me)
+	}
+
+	legal := LegalPlays(state, hands[turn])
+	if len(legal) == 0 {
+		state.Passed[turn] = true
+		return pegContinue(state, hands, me)
+	}
+
+	best := 0
+	seen := [14]bool{}
+	for i, card := range legal {
+		// Suits never score while pegging, so one card of each Rank is enough
+		if seen[card.Rank] {
+			continue
+		}
+		seen[card.Rank] = true
+
+		next := state
+		next.CardPile = append(slices.Clip(state.CardPile), card)
+		next.Sum += card.ValueMax10()
+		next.LastPlayer = turn
+		points := pegSign(turn, me) * pegPilePoints(next)
+
+		after := [2]Hand{hands[0], hands[1]}
+		after[turn] = difference(hands[turn], Hand{card})
+		value := points + pegContinue(next, after, me)
+
+		if i == 0 || (turn == me && value > best) || (turn != me && value < best) {
+			best = value
+		}
+	}
+	return best
+}
+
+// start a new pile when needed and pass the turn
+func pegContinue(state PegState, hands [2]Hand, me int) int {
+	value := 0
+	if state.ShouldReset() {
+		if state.Sum != 31 {
+			value += pegSign(state.LastPlayer, me)
+		}
+		state.Reset()
+	}
+	state.Turn = 1 - state.Turn
+	return value + PegMinimax(state, hands, me)
+}
+
+// points for the last card on the pile (already added to the state)
+func pegPilePoints(s PegState) int {
+	points := 0
+	if s.Sum == 15 || s.Sum == 31 {
+		points += 2
+	}
+	return points + ScorePegPairs(s.CardPile) + ScorePegRuns(s.CardPile)
+}
+
+func pegSign(player, me int) int {
+	if player == me {
+		return 1
+	}
+	return -1
+}
+
+// Expected result of one legal play from a PlayerView
+type PlayValue struct {
+	Card       Card
+	Points     int     // scored immediately by the play
+	Reply      float64 // expected points of the opponent's best next card
+	WorstReply int     // most points any unseen card can score in reply
+	Net        float64 // expected net points for the rest of the play, including Points
+}
+
+// most points opp scores with one card of hand after the play
+// that made state, 0 when the pile is finished at 31 or opp said Go
+func replyPoints(state PegState, hand Hand, opp int) int {
+	if state.Sum == 31 || state.Passed[opp] {
+		return 0
+	}
+	best := 0
+	for _, card := range LegalPlays(state, hand) {
+		points, _ := ScorePeggingPlay(state, card)
+		best = max(best, points)
+	}
+	return best
+}
+
+// Score every legal play with a full lookahead search. The opponent's hidden
+// cards are sampled from the OpponentCards (samples hands, the same for every play),
+// and both Players then play their best. Returns plays in hand order.
+func EvaluatePegPlays(view PlayerView, samples int, rng *rand.Rand) []PlayValue {
+	legal := LegalPlays(view.PegState, view.Hand)
+	values := make([]PlayValue, len(legal))
+	for i, card := range legal {
+		values[i].Card = card
+		values[i].Points, _ = ScorePeggingPlay(view.PegState, card)
+	}
+	if len(legal) == 0 {
+		return values
+	}
+
+	me, opp := view.Seat, view.Opponent()
+	unseen := view.OpponentCards()
+	if view.Remaining[opp] > 0 {
+		for i, card := range legal {
+			values[i].WorstReply = replyPoints(afterPlay(view.PegState, card, me), unseen, opp)
+		}
+	}
+	for range samples {
+		rng.Shuffle(len(unseen), func(i, j int) { unseen[i], unseen[j] = unseen[j], unseen[i] })
+		var hands [2]Hand
+		hands[me] = view.Hand
+		hands[opp] = unseen[:min(view.Remaining[opp], len(unseen))]
+
+		for i, card := range legal {
+			state := afterPlay(view.PegState, card, me)
+			values[i].Reply += float64(replyPoints(state, hands[opp], opp))
+
+			after := hands
+			after[me] = difference(view.Hand, Hand{card})
+			values[i].Net += float64(values[i].Points + pegContinue(state, after, me))
+		}
+	}
+	for i := range values {
+		values[i].Reply /= float64(samples)
+		values[i].Net /= float64(samples)
+	}
+	return values
+}
+
+// opponent hands sampled for the pegging analysis
+const analysisSamples = 300
+
+// state after seat plays card, before the pile is reset
+func afterPlay(state PegState, card Card, seat int) PegState {
+	state.Turn = seat
+	state.CardPile = append(slices.Clip(state.CardPile), card)
+	state.Sum += card.ValueMax10()
+	state.LastPlayer = seat
+	return state
+}
+
+// Every legal play from the view with its immediate points, the opponent's
+// reply and the expected net points, best first. Pegging counterpart of DiscardAnalysis.
+func PeggingAnalysis(view PlayerView, samples int) {
+	rng := fixedRand(1)
+	values := EvaluatePegPlays(view, samples, rng)
+	sort.SliceStable(values, func(i, j int) bool { return values[i].Net > values[j].Net })
+
+	msg := "--- PEGGING ANALYSIS ---"
+	fmt.Println(msg)
+	fmt.Printf("Count %d: %s\n", view.Sum, view.CardPile)
+	if len(values) == 0 {
+		fmt.Println("No legal play: say Go")
+	} else {
+		fmt.Printf("%-5s %5s %10s %10s %7s\n", "Card", "Now", "Reply avg", "Reply max", "Net")
+	}
+	for _, v := range values {
+		fmt.Printf("%-5s %+5d %10.2f %10d %+7.2f\n", v.Card, v.Points, v.Reply, v.WorstReply, v.Net)
+	}
+	fmt.Println(strings.Repeat("-", len(msg)))
+}
@@ -0,0 +1,103 @@
+package cribbage
+
+// File contains the total value of a discard: show points, crib points and pegging points
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+)
+
+// opponent hands sampled to estimate the pegging value of a kept hand
+const pegValueSamples = 300
+
+// Expected pegging points of a kept hand against a sampled opponent hand
+type PegValue struct {
+	Earned   float64
+	Conceded float64
+}
+
+func (v PegValue) Net() float64 {
+	return v.Earned - v.Conceded
+}
+
+// Peg the Keep against opponents holding 4 random cards that are not in the
+// dealt hand, with greedy play on both sides. The pone leads.
+// hands are the sampled opponent hands, so every option can share them.
+func (opt DiscardOption) PegValue(isDealer bool, hands []Hand) PegValue {
+	me := 0
+	if isDealer {
+		me = 1
+	}
+	greedy := [2]PegChooser{GreedyPegChooser, GreedyPegChooser}
+
+	var value PegValue
+	for _, opp := range hands {
+		var players [2]Hand
+		players[me], players[1-me] = opt.Keep, opp
+		points := PegPlayout(PegState{Turn: 0, CardPile: Hand{}}, players, greedy)
+		value.Earned += float64(points[me])
+		value.Conceded += float64(points[1-me])
+	}
+	value.Earned /= float64(len(hands))
+	value.Conceded /= float64(len(hands))
+	return value
+}
+
+// samples opponent hands of 4 cards not in the dealt hand
+func sampleOpponentHands(dealt Hand, samples int, rng *rand.Rand) []Hand {
+	unseen := difference(Hand(NewDeck()), dealt)
+	hands := make([]Hand, samples)
+	for i := range hands {
+		rng.Shuffle(len(unseen), func(i, j int) { unseen[i], unseen[j] = unseen[j], unseen[i] })
+		hands[i] = append(Hand{}, unseen[:4]...)
+	}
+	return hands
+}
+
+// Show, crib and pegging value of one DiscardOption.
+// Crib is counted for the dealer and against the pone.
+type TotalEval struct {
+	Option DiscardOption
+	Hand   float64 // average Show points of the Keep
+	Crib   float64 // average crib points, negative for the pone
+	Peg    PegValue
+	Total  float64
+}
+
+// Evaluate every option with the same sampled opponent hands, best Total first
+func EvaluateTotals(options []DiscardOption, isDealer bool, samples int, rng *rand.Rand) []TotalEval {
+	dealt := append(append(Hand{}, options[0].Keep...), options[0].Discard...)
+	hands := sampleOpponentHands(dealt, samples, rng)
+
+	totals := make([]TotalEval, len(options))
+	for i, opt := range options {
+		t := TotalEval{Option: opt}
+		t.Hand = opt.HandDist().Mean()
+		t.Crib = opt.CribDist(isDealer).Mean()
+		if !isDealer {
+			t.Crib = -t.Crib
+		}
+		t.Peg = opt.PegValue(isDealer, hands)
+		t.Total = t.Hand + t.Crib + t.Peg.Net()
+		totals[i] = t
+	}
+	sort.SliceStable(totals, func(i, j int) bool { return totals[i].Total > totals[j].Total })
+	return totals
+}
+
+// Every discard ranked by show, crib and pegging points together
+func TotalAnalysis(options []DiscardOption, isDealer bool) {
+	rng := fixedRand(1)
+	totals := EvaluateTotals(options, isDealer, pegValueSamples, rng)
+
+	msg := "--- DISCARDS WITH PEGGING ---"
+	fmt.Println(msg)
+	fmt.Printf("%-8s %-12s %6s %6s %6s %6s %7s\n", "Throw", "Keep", "Hand", "Crib", "Peg+", "Peg-", "Total")
+	for _, t := range totals {
+		fmt.Printf("%-8s %-12s %6.2f %+6.2f %6.2f %6.2f %7.2f\n",
+			t.Option.Discard, t.Option.Keep, t.Hand, t.Crib, t.Peg.Earned, t.Peg.Conceded, t.Total)
+	}
+	fmt.Println(strings.Repeat("-", len(msg)))
+}
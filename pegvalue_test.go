@@ -0,0 +1,35 @@
+package cribbage
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestEvaluateTotals(t *testing.T) {
+	dealt, _ := ParseHand("5H 5S 6D 7C KD QH")
+	totals := EvaluateTotals(dealt.Split(4), false, 100, rand.New(rand.NewSource(1)))
+	if len(totals) != 15 {
+		t.Fatalf("got %d totals, want 15", len(totals))
+	}
+	for i, total := range totals {
+		if i > 0 && total.Total > totals[i-1].Total {
+			t.Fatalf("totals not sorted at %d", i)
+		}
+		if math.Abs(total.Total-(total.Hand+total.Crib+total.Peg.Net())) > 1e-9 || total.Crib > 0 {
+			t.Fatalf("got %+v", total)
+		}
+	}
+}
+
+// The dealer pegs more: the pone leads and the dealer often gets the last card
+func TestDiscardOption_PegValue(t *testing.T) {
+	dealt, _ := ParseHand("5H 5S 6D 7C KD QH")
+	opt := DiscardOption{Keep: Hand{dealt[0], dealt[1], dealt[2], dealt[3]}, Discard: Hand{dealt[4], dealt[5]}}
+	hands := sampleOpponentHands(dealt, 300, rand.New(rand.NewSource(1)))
+
+	dealer, pone := opt.PegValue(true, hands), opt.PegValue(false, hands)
+	if dealer.Net() <= 0 || dealer.Net() <= pone.Net() {
+		t.Fatalf("dealer %+v, pone %+v", dealer, pone)
+	}
+}
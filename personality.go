@@ -0,0 +1,128 @@
+package cribbage
+
+// File contains computer personalities that play like a person, mistakes included
+
+import (
+	"math"
+	"math/rand"
+	"strings"
+)
+
+// Play style of a ComputerPlayer. A nil Personality counts, discards and
+// pegs as well as the computer can.
+type Personality struct {
+	Name string
+	// chance of missing each fifteen when counting, the points can be
+	// taken by the opponent under Muggins
+	MissRate float64
+	// softmax temperature in points over the ExpectedValue of the discards,
+	// 0 always throws the best discard
+	Temperature float64
+	// pegging weight from 0 (only avoids the opponent's replies)
+	// to 1 (only takes points now)
+	Aggression float64
+	// seed of the Personality's Rand, so the same deals get the same mistakes.
+	// Set by the caller, Start takes it from a prompt or the time.
+	Seed int64
+
+	random *rand.Rand
+}
+
+var Personalities = []Personality{
+	{Name: "Novice", MissRate: 0.3, Temperature: 2, Aggression: 0.9},
+	{Name: "Casual", MissRate: 0.1, Temperature: 0.75, Aggression: 0.6},
+}
+
+// Personality with the name, ignoring case
+func FindPersonality(name string) (Personality, bool) {
+	for _, p := range Personalities {
+		if strings.EqualFold(p.Name, name) {
+			return p, true
+		}
+	}
+	return Personality{}, false
+}
+
+// rng of the mistakes, seeded by Seed on first use
+func (p *Personality) Rand() *rand.Rand {
+	if p.random == nil {
+		p.random = rand.New(rand.NewSource(p.Seed))
+	}
+	return p.random
+}
+
+// Scoring items a person calls out, each fifteen missed with MissRate
+func (p *Personality) Count(hand Hand, cut Card, isCrib bool, rng *rand.Rand) ScoreItems {
+	items := ScoreItems{}
+	for _, item := range hand.Itemize(cut, isCrib) {
+		if item.Category == "Fifteens" && rng.Float64() < p.MissRate {
+			continue
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+// Discard drawn with probability proportional to exp(ExpectedValue / Temperature)
+func (p *Personality) Discard(options []DiscardOption, isDealer bool, rng *rand.Rand) DiscardOption {
+	if p.Temperature <= 0 {
+		return OptimalDiscard(options, isDealer)
+	}
+	evs := make([]float64, len(options))
+	best := math.Inf(-1)
+	for i, option := range options {
+		evs[i] = option.ExpectedValue(isDealer)
+		best = max(best, evs[i])
+	}
+	weights := make([]float64, len(options))
+	total := 0.0
+	for i, ev := range evs {
+		weights[i] = math.Exp((ev - best) / p.Temperature)
+		total += weights[i]
+	}
+	pick := rng.Float64() * total
+	for i, w := range weights {
+		if pick < w {
+			return options[i]
+		}
+		pick -= w
+	}
+	return options[len(options)-1]
+}
+
+// Play by Aggression * points - (1 - Aggression) * average reply, where the
+// reply is what one of the OpponentCards would score. An opponent who said
+// Go on this pile has no reply. Ties are broken at random.
+func (p *Personality) PegPlay(view PlayerView, rng *rand.Rand) (Card, bool) {
+	legal := LegalPlays(view.PegState, view.Hand)
+	if len(legal) == 0 {
+		return Card{}, false
+	}
+	opp := view.Opponent()
+	pool := view.OpponentCards()
+	replies := view.Remaining[opp] > 0 && len(pool) > 0
+
+	var best Hand
+	bestValue := math.Inf(-1)
+	for _, card := range legal {
+		points, _ := ScorePeggingPlay(view.PegState, card)
+		reply := 0.0
+		if after := afterPlay(view.PegState, card, view.Seat); replies && !after.Passed[opp] {
+			for _, c := range pool {
+				if after.Sum != 31 && c.ValueMax10() <= 31-after.Sum {
+					r, _ := ScorePeggingPlay(after, c)
+					reply += float64(r)
+				}
+			}
+			reply /= float64(len(pool))
+		}
+		value := p.Aggression*float64(points) - (1-p.Aggression)*reply
+		switch {
+		case value > bestValue+1e-9:
+			best, bestValue = Hand{card}, value
+		case value > bestValue-1e-9:
+			best = append(best, card)
+		}
+	}
+	return best[rng.Intn(len(best))], true
+}
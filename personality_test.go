@@ -0,0 +1,140 @@
+package cribbage
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestPersonality_Count(t *testing.T) {
+	hand, _ := ParseHand("5H 5S 5D JC")
+	cut := Card{Five, Clubs, Black}
+	tests := []struct {
+		missRate float64
+		want     int
+	}{
+		{0, 29},
+		// every fifteen missed: 12 for pairs and 1 for nobs
+		{1, 13},
+	}
+	for _, tt := range tests {
+		p := &Personality{MissRate: tt.missRate}
+		if got := p.Count(hand, cut, false, rand.New(rand.NewSource(1))).Total(); got != tt.want {
+			t.Fatalf("miss rate %.1f: counted %d, want %d", tt.missRate, got, tt.want)
+		}
+	}
+}
+
+func TestPersonality_Discard(t *testing.T) {
+	dealt, _ := ParseHand("5H 5S 6D 7C KD QH")
+	options := dealt.Split(4)
+	best := OptimalDiscard(options, false)
+
+	exact := &Personality{}
+	if got := exact.Discard(options, false, rand.New(rand.NewSource(1))); got.Discard.Set() != best.Discard.Set() {
+		t.Fatalf("temperature 0 threw %s, want %s", got.Discard, best.Discard)
+	}
+
+	// the same Seed makes the same mistakes
+	throws := func() []Hand {
+		p := &Personality{Temperature: 3, Seed: 7}
+		var discards []Hand
+		for range 20 {
+			discards = append(discards, p.Discard(options, false, p.Rand()).Discard)
+		}
+		return discards
+	}
+	first, second := throws(), throws()
+	mistakes := 0
+	for i := range first {
+		if first[i].Set() != second[i].Set() {
+			t.Fatalf("throw %d: %s then %s with the same seed", i, first[i], second[i])
+		}
+		if first[i].Set() != best.Discard.Set() {
+			mistakes++
+		}
+	}
+	if mistakes == 0 {
+		t.Fatalf("temperature 3 always threw %s", best.Discard)
+	}
+}
+
+func TestPersonality_PegPlay(t *testing.T) {
+	five, four := Card{Five, Hearts, Red}, Card{Four, Clubs, Black}
+	view := PlayerView{
+		PegState:  PegState{CardPile: Hand{}},
+		Hand:      Hand{five, four},
+		Dealer:    1,
+		Remaining: [2]int{2, 4},
+	}
+	// a lead of 5 gives the opponent a fifteen with any ten
+	cautious := &Personality{Aggression: 0}
+	if got, ok := cautious.PegPlay(view, rand.New(rand.NewSource(1))); !ok || got != four {
+		t.Fatalf("aggression 0 led %s, want %s", got, four)
+	}
+
+	// on a count of 10, the aggressive player takes the fifteen
+	ten := Card{Ten, Spades, Black}
+	view.PegState = PegState{Sum: 10, CardPile: Hand{ten}, LastPlayer: 1}
+	view.Played[1] = Hand{ten}
+	view.Remaining[1] = 3
+	p := &Personality{Aggression: 1}
+	if got, _ := p.PegPlay(view, rand.New(rand.NewSource(1))); got != five {
+		t.Fatalf("aggression 1 played %s on 10, want %s", got, five)
+	}
+}
+
+// After the opponent's Go no reply can score, so a cautious player has no
+// reason to prefer the Jack (an Ace makes 31) over the 4 (a 7 makes 31)
+func TestPersonality_PegPlayAfterGo(t *testing.T) {
+	pile, _ := ParseHand("KC QD")
+	jack, four := Card{Jack, Hearts, Red}, Card{Four, Spades, Black}
+	view := PlayerView{
+		PegState:  PegState{Sum: 20, CardPile: pile, LastPlayer: 0, Passed: [2]bool{false, true}},
+		Hand:      Hand{jack, four},
+		Remaining: [2]int{2, 3},
+	}
+	view.Played[0] = Hand{pile[0]}
+	view.Played[1] = Hand{pile[1]}
+	view.Goes[1] = []PegGo{{Count: 20}}
+
+	cautious := &Personality{Aggression: 0}
+	played := map[Card]bool{}
+	for seed := range int64(20) {
+		card, _ := cautious.PegPlay(view, rand.New(rand.NewSource(seed)))
+		played[card] = true
+	}
+	if !played[jack] || !played[four] {
+		t.Fatalf("after a Go the plays were not tied: %v", played)
+	}
+}
+
+func TestGame_CallMuggins(t *testing.T) {
+	hand, _ := ParseHand("5H 5S 5D JC")
+	p1 := &ComputerPlayer{Name: "P1", Personality: &Personality{MissRate: 1}}
+	p2 := &ComputerPlayer{Name: "P2"}
+	game := &Game{Players: [2]Player{p1, p2}, Cut: Card{Five, Clubs, Black}}
+
+	// without Muggins missed points stay missed
+	game.CallMuggins(0, hand, false, 13)
+	if p2.Points != 0 {
+		t.Fatalf("took %d points without muggins", p2.Points)
+	}
+
+	game.Muggins = true
+	game.CallMuggins(0, hand, false, 13)
+	if p2.Points != 16 {
+		t.Fatalf("muggins took %d points, want 16", p2.Points)
+	}
+
+	// a perfect counter is not called
+	p1.Points = 0
+	game.CallMuggins(1, hand, false, 13)
+	if p1.Points != 0 {
+		t.Fatalf("took %d points from a perfect counter", p1.Points)
+	}
+
+	p2.Points = 110
+	if !game.CallMuggins(0, hand, false, 29-16) || game.Winner != 1 {
+		t.Fatalf("muggins to %d did not win the game", p2.Points)
+	}
+}
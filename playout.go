@@ -0,0 +1,55 @@
+package cribbage
+
+// File contains a silent Pegging loop for simulations, following the rules of StartPegging
+
+// picks a Card to play from the legal cards, which are never empty
+type PegChooser func(state PegState, hand Hand, legal Hand) Card
+
+// greedy choice with OptimalPegging, else the first legal Card
+func GreedyPegChooser(state PegState, hand Hand, legal Hand) Card {
+	if best, ok := OptimalPegging(state, legal); ok {
+		return best
+	}
+	return legal[0]
+}
+
+// Peg both hands from the given state until all cards are played, without printing.
+// Returns the points scored by each Player, including Go and last card points.
+// The state and hands are copied, state.Turn is the next Player to act.
+func PegPlayout(state PegState, hands [2]Hand, choose [2]PegChooser) [2]int {
+	var points [2]int
+	state.CardPile = append(Hand{}, state.CardPile...)
+	hands = [2]Hand{append(Hand{}, hands[0]...), append(Hand{}, hands[1]...)}
+
+	for len(hands[0]) > 0 || len(hands[1]) > 0 {
+		if state.Passed[state.Turn] {
+			state.Turn = 1 - state.Turn
+			continue
+		}
+
+		legal := LegalPlays(state, hands[state.Turn])
+		if len(legal) == 0 {
+			state.Passed[state.Turn] = true
+		} else {
+			card := choose[state.Turn](state, hands[state.Turn], legal)
+			p, _ := ScorePeggingPlay(state, card)
+			points[state.Turn] += p
+			state.mustAddCard(card)
+			hands[state.Turn] = difference(hands[state.Turn], Hand{card})
+		}
+
+		if state.ShouldReset() {
+			if state.Sum != 31 {
+				points[state.LastPlayer]++
+			}
+			state.Reset()
+		}
+		state.Turn = 1 - state.Turn
+	}
+
+	// last card of the final pile
+	if state.Sum != 0 {
+		points[state.LastPlayer]++
+	}
+	return points
+}
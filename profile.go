@@ -0,0 +1,207 @@
+package cribbage
+
+// File contains player profiles with lifetime statistics saved across sessions
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// file name of the profiles in DataDir
+const ProfilesFile = "profiles.json"
+
+// Lifetime statistics of one named player
+type Profile struct {
+	Name           string
+	Games          int
+	Wins           int
+	SkunksGiven    int
+	SkunksReceived int
+	Hands          int // hands counted in the show, not including cribs
+	HandPoints     int
+	Cribs          int
+	CribPoints     int
+	PegRounds      int // rounds with a finished or interrupted pegging play
+	PegPoints      int
+	BestHand       *HandCount `json:",omitempty"`
+	Counts         int        // hands counted by the player with CountHand
+	CountsCorrect  int
+}
+
+func ratio(n, d int) float64 {
+	if d == 0 {
+		return 0
+	}
+	return float64(n) / float64(d)
+}
+
+func (p *Profile) AverageHand() float64    { return ratio(p.HandPoints, p.Hands) }
+func (p *Profile) AverageCrib() float64    { return ratio(p.CribPoints, p.Cribs) }
+func (p *Profile) AveragePegging() float64 { return ratio(p.PegPoints, p.PegRounds) }
+func (p *Profile) CountAccuracy() float64  { return ratio(p.CountsCorrect, p.Counts) }
+
+func (p *Profile) RecordCount(correct bool) {
+	p.Counts++
+	if correct {
+		p.CountsCorrect++
+	}
+}
+
+// Add the finished game played from seat
+func (p *Profile) RecordGame(game *Game, seat int) {
+	p.Games++
+	skunk := SkunkLevel(game.Players[1-game.Winner].GetScore()) > 0
+	if game.Winner == seat {
+		p.Wins++
+		if skunk {
+			p.SkunksGiven++
+		}
+	} else if skunk {
+		p.SkunksReceived++
+	}
+
+	for _, points := range game.History.Pegging {
+		p.PegRounds++
+		p.PegPoints += points[seat]
+	}
+	for _, count := range game.History.Counts {
+		if count.Seat != seat {
+			continue
+		}
+		if count.IsCrib {
+			p.Cribs++
+			p.CribPoints += count.Points
+			continue
+		}
+		p.Hands++
+		p.HandPoints += count.Points
+		if p.BestHand == nil || count.Points > p.BestHand.Points {
+			best := count
+			p.BestHand = &best
+		}
+	}
+}
+
+func (p *Profile) Print() {
+	msg := fmt.Sprintf("--- %s ---", p.Name)
+	fmt.Println(msg)
+	fmt.Printf("Games: %d, Wins: %d (%.0f%%)\n", p.Games, p.Wins, 100*ratio(p.Wins, p.Games))
+	fmt.Printf("Skunks given: %d, received: %d\n", p.SkunksGiven, p.SkunksReceived)
+	fmt.Printf("Average hand: %.2f (%d hands)\n", p.AverageHand(), p.Hands)
+	fmt.Printf("Average crib: %.2f (%d cribs)\n", p.AverageCrib(), p.Cribs)
+	fmt.Printf("Average pegging per hand: %.2f\n", p.AveragePegging())
+	if p.BestHand != nil {
+		fmt.Printf("Best hand: %s  Cut: %s (%d points)\n", p.BestHand.Hand, p.BestHand.Cut, p.BestHand.Points)
+	}
+	if p.Counts > 0 {
+		fmt.Printf("Counting accuracy: %d / %d (%.0f%%)\n", p.CountsCorrect, p.Counts, 100*p.CountAccuracy())
+	}
+	fmt.Println(strings.Repeat("-", len(msg)))
+}
+
+// Profiles saved to one JSON file, by lowercase name
+type ProfileStore struct {
+	Path     string
+	Profiles map[string]*Profile
+}
+
+// Profiles from a JSON file, or none if the file does not exist yet
+func LoadProfiles(path string) (*ProfileStore, error) {
+	store := &ProfileStore{Path: path, Profiles: make(map[string]*Profile)}
+	if _, err := loadJSON(path, &store.Profiles); err != nil {
+		return nil, err
+	}
+	return store, nil
+}
+
+func (s *ProfileStore) Save() error {
+	return saveJSON(s.Path, s.Profiles)
+}
+
+// Profile of name, created if it is new
+func (s *ProfileStore) Get(name string) *Profile {
+	key := strings.ToLower(name)
+	if p, ok := s.Profiles[key]; ok {
+		return p
+	}
+	p := &Profile{Name: name}
+	s.Profiles[key] = p
+	return p
+}
+
+// Profiles sorted by name
+func (s *ProfileStore) List() []*Profile {
+	list := make([]*Profile, 0, len(s.Profiles))
+	for _, p := range s.Profiles {
+		list = append(list, p)
+	}
+	sort.Slice(list, func(i, j int) bool { return strings.ToLower(list[i].Name) < strings.ToLower(list[j].Name) })
+	return list
+}
+
+// Pick a saved profile by index, or type a name for a new one
+func (s *ProfileStore) Choose() *Profile {
+	reader := bufio.NewReader(os.Stdin)
+	list := s.List()
+	for {
+		if len(list) > 0 {
+			fmt.Println("Saved players:")
+			for i, p := range list {
+				fmt.Printf(" %d. %s\n", i+1, p.Name)
+			}
+			fmt.Print("Select a player index or provide your name: ")
+		} else {
+			fmt.Print("Please provide your name: ")
+		}
+		input, _ := reader.ReadString('\n')
+		input = strings.TrimSpace(input)
+		if i, err := strconv.Atoi(input); err == nil && i >= 1 && i <= len(list) {
+			return list[i-1]
+		}
+		if input != "" {
+			return s.Get(input)
+		}
+	}
+}
+
+// Add the finished game to the profile of each HumanPlayer and save them
+func (game *Game) RecordProfiles() {
+	if game.Profiles == nil {
+		return
+	}
+	for seat, player := range game.Players {
+		if human, ok := player.(*HumanPlayer); ok && human.Profile != nil {
+			human.Profile.RecordGame(game, seat)
+		}
+	}
+	if err := game.Profiles.Save(); err != nil {
+		fmt.Printf("Could not save profiles: %v\n", err)
+	}
+}
+
+// Print every saved profile, or only the one of name
+func PrintProfiles(path string, name string) error {
+	store, err := LoadProfiles(path)
+	if err != nil {
+		return err
+	}
+	if name != "" {
+		p, ok := store.Profiles[strings.ToLower(name)]
+		if !ok {
+			return fmt.Errorf("no profile named %q in %s", name, path)
+		}
+		p.Print()
+		return nil
+	}
+	if len(store.Profiles) == 0 {
+		fmt.Printf("No saved profiles in %s\n", path)
+	}
+	for _, p := range store.List() {
+		p.Print()
+	}
+	return nil
+}
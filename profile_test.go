@@ -0,0 +1,53 @@
+package cribbage
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestProfile_RecordGame(t *testing.T) {
+	hand, _ := ParseHand("5H 5S 5D JC")
+	crib, _ := ParseHand("AH 2S 9D KC")
+	cut, _ := ParseCard("5C")
+	game := &Game{
+		Players: [2]Player{&HumanPlayer{Name: "Ann", Points: 121}, &ComputerPlayer{Name: "COM 1", Points: 80}},
+		Winner:  0,
+		GameWon: true,
+		History: GameHistory{
+			Pegging: [][2]int{{4, 1}, {2, 6}},
+			Counts: []HandCount{
+				{Seat: 0, Hand: hand, Cut: cut, Points: 29},
+				{Seat: 1, Hand: crib, Cut: cut, Points: 0},
+				{Seat: 0, Hand: crib, Cut: cut, IsCrib: true, Points: 3},
+			},
+		},
+	}
+
+	path := filepath.Join(t.TempDir(), ProfilesFile)
+	store, err := LoadProfiles(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := store.Get("Ann")
+	p.RecordGame(game, 0)
+	p.RecordCount(true)
+	p.RecordCount(false)
+	if err := store.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := LoadProfiles(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := loaded.Get("ann")
+	if got.Games != 1 || got.Wins != 1 || got.SkunksGiven != 1 || got.SkunksReceived != 0 {
+		t.Fatalf("got games %+v", got)
+	}
+	if got.AverageHand() != 29 || got.AverageCrib() != 3 || got.AveragePegging() != 3 {
+		t.Fatalf("got averages %.2f %.2f %.2f, want 29 3 3", got.AverageHand(), got.AverageCrib(), got.AveragePegging())
+	}
+	if got.BestHand == nil || got.BestHand.Points != 29 || got.CountAccuracy() != 0.5 {
+		t.Fatalf("got best hand %+v, accuracy %.2f", got.BestHand, got.CountAccuracy())
+	}
+}
@@ -0,0 +1,170 @@
+package cribbage
+
+// File contains the record of every decision in a Game and the post-game review
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+type DiscardDecision struct {
+	Round   int
+	View    PlayerView // Hand holds the 6 dealt cards
+	Discard Hand
+}
+
+type PegDecision struct {
+	Round int
+	View  PlayerView
+	Card  Card
+}
+
+// Points of one hand or crib in the show
+type HandCount struct {
+	Round  int
+	Seat   int
+	Hand   Hand
+	Cut    Card
+	IsCrib bool
+	Points int
+}
+
+type GameHistory struct {
+	Discards []DiscardDecision
+	Plays    []PegDecision
+	Pegging  [][2]int // pegging points of each Player by round
+	Counts   []HandCount
+}
+
+// expected points lost below this are not reported as mistakes
+const DefaultReviewThreshold = 0.5
+
+// opponent hands sampled for each reviewed pegging play
+const reviewSamples = 60
+
+type Mistake struct {
+	Round  int
+	Seat   int
+	Phase  string // "Discard" or "Pegging"
+	Chosen string
+	Best   string
+	Loss   float64 // expected points given away
+}
+
+// Expected points given away by each Player in each phase
+type Review struct {
+	Names     [2]string
+	Threshold float64
+	Mistakes  []Mistake
+	Discard   [2]float64
+	Pegging   [2]float64
+}
+
+// Re-evaluate every recorded decision of the game: discards by ExpectedValue,
+// pegging plays by EvaluatePegPlays. Losses above threshold are Mistakes.
+func (game *Game) Review(threshold float64) Review {
+	review := Review{Threshold: threshold}
+	for i, player := range game.Players {
+		review.Names[i] = player.GetName()
+	}
+
+	for _, d := range game.History.Discards {
+		evals, _ := EvaluateDiscards(context.Background(), d.View.Hand.Split(4), d.View.IsDealer())
+		best, chosen := evals[0], evals[0]
+		for _, eval := range evals {
+			if eval.EV > best.EV {
+				best = eval
+			}
+			if eval.Option.Discard.Set() == d.Discard.Set() {
+				chosen = eval
+			}
+		}
+
+		loss := best.EV - chosen.EV
+		review.Discard[d.View.Seat] += loss
+		if loss > threshold {
+			review.Mistakes = append(review.Mistakes, Mistake{
+				Round:  d.Round,
+				Seat:   d.View.Seat,
+				Phase:  "Discard",
+				Chosen: fmt.Sprintf("threw %s (%.2f)", d.Discard, chosen.EV),
+				Best:   fmt.Sprintf("throw %s (%.2f)", best.Option.Discard, best.EV),
+				Loss:   loss,
+			})
+		}
+	}
+
+	rng := fixedRand(1)
+	for _, d := range game.History.Plays {
+		values := EvaluatePegPlays(d.View, reviewSamples, rng)
+		if len(values) < 2 {
+			// no choice to make
+			continue
+		}
+		best, chosen := values[0], values[0]
+		for _, v := range values {
+			if v.Net > best.Net {
+				best = v
+			}
+			if v.Card == d.Card {
+				chosen = v
+			}
+		}
+
+		loss := best.Net - chosen.Net
+		review.Pegging[d.View.Seat] += loss
+		if loss > threshold {
+			review.Mistakes = append(review.Mistakes, Mistake{
+				Round:  d.Round,
+				Seat:   d.View.Seat,
+				Phase:  "Pegging",
+				Chosen: fmt.Sprintf("played %s on %d (%+.2f)", d.Card, d.View.Sum, chosen.Net),
+				Best:   fmt.Sprintf("play %s (%+.2f)", best.Card, best.Net),
+				Loss:   loss,
+			})
+		}
+	}
+	return review
+}
+
+func (r Review) Print() {
+	msg := "--- GAME REVIEW ---"
+	fmt.Println(msg)
+	if len(r.Mistakes) == 0 {
+		fmt.Printf("No decision lost more than %.1f expected points\n", r.Threshold)
+	}
+	for _, m := range r.Mistakes {
+		fmt.Printf("Round %d, %s (%s): %s, better to %s  -%.2f\n",
+			m.Round+1, r.Names[m.Seat], m.Phase, m.Chosen, m.Best, m.Loss)
+	}
+
+	fmt.Println("\nExpected points given away")
+	for i, name := range r.Names {
+		fmt.Printf("%s: Discard %.2f, Pegging %.2f, Total %.2f\n",
+			name, r.Discard[i], r.Pegging[i], r.Discard[i]+r.Pegging[i])
+	}
+	fmt.Println(strings.Repeat("-", len(msg)))
+}
+
+// Ask a human Player whether to review the finished game
+func (game *Game) OfferReview() {
+	human := false
+	for _, player := range game.Players {
+		if _, ok := player.(*HumanPlayer); ok {
+			human = true
+		}
+	}
+	if !human {
+		return
+	}
+
+	var input string
+	fmt.Print("\nReview the game's decisions? [y/n]: ")
+	fmt.Scanln(&input)
+	switch strings.ToLower(input) {
+	case "yes", "y":
+		fmt.Println()
+		game.Review(DefaultReviewThreshold).Print()
+	}
+}
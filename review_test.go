@@ -0,0 +1,54 @@
+package cribbage
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// Pone leads a 5 and the dealer makes 15 with a 10, then takes the last card
+func TestPegMinimax_KnownHands(t *testing.T) {
+	state := PegState{Turn: 0, CardPile: Hand{}}
+	hands := [2]Hand{{{Rank: Five, Suit: Clubs}}, {{Rank: Ten, Suit: Hearts}}}
+	if got := PegMinimax(state, hands, 0); got != -3 {
+		t.Fatalf("got %d, want -3", got)
+	}
+	// agrees with the playout when each Player has one choice
+	points := PegPlayout(state, hands, [2]PegChooser{GreedyPegChooser, GreedyPegChooser})
+	if points[0]-points[1] != -3 {
+		t.Fatalf("playout net %d, want -3", points[0]-points[1])
+	}
+}
+
+func TestReview_FlagsBadDiscard(t *testing.T) {
+	game := &Game{Players: [2]Player{&ComputerPlayer{Name: "P1"}, &ComputerPlayer{Name: "P2"}}}
+	dealt := testDealt()
+	game.History.Discards = []DiscardDecision{{
+		View: PlayerView{Seat: 0, Dealer: 0, Hand: dealt},
+		// throwing both 5s into the crib breaks up the fifteens
+		Discard: Hand{dealt[0], dealt[1]},
+	}}
+
+	review := game.Review(DefaultReviewThreshold)
+	if len(review.Mistakes) != 1 || review.Mistakes[0].Phase != "Discard" || review.Discard[0] <= DefaultReviewThreshold {
+		t.Fatalf("got %+v", review)
+	}
+}
+
+func TestEvaluatePegPlays_PairIsRisky(t *testing.T) {
+	// pairing a 7 on 7 (14) invites a pair royal; the 8 makes 15 for 2
+	view := PlayerView{
+		PegState:  PegState{Sum: 7, CardPile: Hand{{Rank: Seven, Suit: Clubs}}},
+		Seat:      0,
+		Hand:      Hand{{Rank: Seven, Suit: Hearts}, {Rank: Eight, Suit: Spades}},
+		Dealer:    1,
+		Remaining: [2]int{2, 3},
+	}
+	values := EvaluatePegPlays(view, 200, rand.New(rand.NewSource(1)))
+	if len(values) != 2 || values[1].Points != 2 || values[1].Net <= values[0].Net {
+		t.Fatalf("got %+v", values)
+	}
+	// 2 sevens can still be played on the pair (6 points), an 8 on 7 8 makes a run at best
+	if values[0].WorstReply != 6 || values[1].WorstReply != 3 || values[0].Reply <= values[1].Reply {
+		t.Fatalf("got replies %+v", values)
+	}
+}
@@ -136,71 +136,37 @@ type ScoreBreakdown struct {
 }
 
 func (h Hand) Score(cut Card, isCrib bool) int {
-	return h.ScoreBreakdown(cut, isCrib).Total
+	if len(h) != 4 {
+		return h.ScoreBreakdown(cut, isCrib).Total
+	}
+	// Fifteens, Pairs and Runs only depend on the 5 Ranks
+	ranks := [5]int{int(h[0].Rank), int(h[1].Rank), int(h[2].Rank), int(h[3].Rank), int(cut.Rank)}
+	return lookupRankPoints(ranks) + Score_flush(h, cut, isCrib) + Score_nobs(h, cut)
 }
 
-// TODO make float64 for machine learn EV
+// Guaranteed points of the 2 cards sent to the crib, without the cut:
+// 2 for a fifteen and 2 for a pair.
+// HeuristicScoreFloat weighs runs, flushes, 5s and Jacks by trained weights.
 func (discard Hand) HeuristicScore() int {
-	// minimum possible points from this hand (i.e. 2 cards sent to Crib)
-	// do not model the cut card
-	// TODO machine learning: other 46 or 50 possible cut cards can influence weights?
-
 	points := 0
 	Card1, Card2 := discard[0], discard[1]
 
-	// Only guaranteed points are by 15, pair
-	// Machine learning for card weight on 15-good, multiple pair, run, flush, nobs
-
 	// 15
 	if Card1.ValueMax10()+Card2.ValueMax10() == 15 {
 		points += 2
 	}
-	// TODO machine learning: rank card values for usefulness in getting 15
 
 	// pair
 	if Card1.Rank == Card2.Rank {
 		points += 2
 	}
-	// TODO machine learning: minor weight on possible 3 or 4 in a row
-
-	// TODO machine learning for weights: possible run in the crib
-	diff := Card1.Value() - Card2.Value()
-	if diff < 0 {
-		diff = -diff
-	}
-	switch diff {
-	case 2:
-		// run of 3
-		points += 0
-	case 3:
-		// run of 4
-		points += 0
-	case 4:
-		// run of 5
-		points += 0
-	}
-
-	// possible flush (requires 5 in crib, very rare)
-	if Card1.Suit == Card2.Suit {
-		points += 0
-	}
-
-	// nobs hardcode probability?
-	// if you discard a Jack and other card
-	if Card1.Rank == Jack || Card2.Rank == Jack {
-		if Card1.Suit == Card2.Suit {
-			// reduce probability???
-		}
-		// expected 1 point * 1/50
-		// or we consider the other 4 in the keep hand.
-		// Move this to DiscardOption.ExpectedValue()?
-	}
 
 	return points
 }
 
 func (h Hand) ScoreBreakdown(cut Card, isDealer bool) ScoreBreakdown {
-	all := append(h, cut)
+	// full slice expression so the cut is never written into h's backing array
+	all := append(h[:len(h):len(h)], cut)
 	sb := ScoreBreakdown{}
 	sb.Fifteens = Score_15(all)
 	sb.Pairs = Score_multiple(all)
@@ -0,0 +1,81 @@
+package cribbage
+
+// File contains a model of the cards a competent opponent throws to the crib
+
+import (
+	"math/rand"
+	"sync"
+)
+
+// Relative likelihood that a Player throws a pair of Ranks to the crib,
+// measured from OptimalDiscard on sampled six-card hands.
+// Weight[role][a][b] has role 0 for the pone and 1 for the dealer;
+// a weight of 1 is as likely as a random throw.
+type ThrowModel struct {
+	Weight [2][14][14]float64
+}
+
+var (
+	defaultThrowModel     *ThrowModel
+	defaultThrowModelOnce sync.Once
+)
+
+// ThrowModel of 3000 deals, built on first use
+func DefaultThrowModel() *ThrowModel {
+	defaultThrowModelOnce.Do(func() {
+		defaultThrowModel = NewThrowModel(fixedRand(46), 3000)
+	})
+	return defaultThrowModel
+}
+
+// Deal hands and count how often each pair of Ranks in a hand is the
+// OptimalDiscard of the pone and of the dealer
+func NewThrowModel(rng *rand.Rand, hands int) *ThrowModel {
+	var thrown [2][14][14]int
+	var held [14][14]int
+	deck := NewDeck()
+
+	for range hands {
+		deck.Shuffle(rng)
+		dealt := Hand(deck[:6])
+		for i := range dealt {
+			for j := i + 1; j < len(dealt); j++ {
+				a, b := dealt[i].Rank, dealt[j].Rank
+				held[a][b]++
+				if a != b {
+					held[b][a]++
+				}
+			}
+		}
+		for role := range 2 {
+			best := OptimalDiscard(dealt.Split(4), role == 1)
+			a, b := best.Discard[0].Rank, best.Discard[1].Rank
+			thrown[role][a][b]++
+			if a != b {
+				thrown[role][b][a]++
+			}
+		}
+	}
+
+	// 1 of the 15 pairs in a hand is thrown, so a random throw has rate 1/15.
+	// Add one random throw to every pair so unseen pairs are not impossible.
+	m := &ThrowModel{}
+	for role := range 2 {
+		for a := Ace; a <= King; a++ {
+			for b := Ace; b <= King; b++ {
+				rate := (float64(thrown[role][a][b]) + 1.0/15) / float64(held[a][b]+1)
+				m.Weight[role][a][b] = 15 * rate
+			}
+		}
+	}
+	return m
+}
+
+// Relative likelihood the dealer (or pone) throws the 2 cards
+func (m *ThrowModel) ThrowWeight(first, second Card, isDealer bool) float64 {
+	role := 0
+	if isDealer {
+		role = 1
+	}
+	return m.Weight[role][first.Rank][second.Rank]
+}
@@ -0,0 +1,33 @@
+package cribbage
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestThrowModel(t *testing.T) {
+	m := NewThrowModel(rand.New(rand.NewSource(1)), 1000)
+	// the pone balks: a five is almost never given to the opponent's crib
+	for r := Ace; r <= King; r++ {
+		if w := m.Weight[0][Five][r]; w >= 1 {
+			t.Fatalf("pone throws 5 with %s at weight %.2f", r, w)
+		}
+	}
+	// the dealer keeps fives together in the crib more than the pone
+	if m.Weight[1][Five][Five] <= m.Weight[0][Five][Five] {
+		t.Fatalf("5 5 weight: dealer %.2f, pone %.2f", m.Weight[1][Five][Five], m.Weight[0][Five][Five])
+	}
+}
+
+// Against a dealer who throws good cards, the pone's crib estimate goes up
+func TestCribDist_ThrowModel(t *testing.T) {
+	m := NewThrowModel(rand.New(rand.NewSource(1)), 1000)
+	dealt, _ := ParseHand("5H 5S 6D 7C KD QH")
+	opt := DiscardOption{Keep: Hand{dealt[0], dealt[1], dealt[2], dealt[3]}, Discard: Hand{dealt[4], dealt[5]}}
+
+	uniform := opt.CribDistWith(false, nil).Mean()
+	modeled := opt.CribDistWith(false, m).Mean()
+	if modeled <= uniform {
+		t.Fatalf("crib with the dealer's throws %.2f, random throws %.2f", modeled, uniform)
+	}
+}
@@ -0,0 +1,136 @@
+package cribbage
+
+// File contains the unseen-card tracker: an assistant panel shown during the play
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Cards the Player has not seen: not in their hand or crib discard,
+// not the cut and not pegged by either Player
+func (v PlayerView) Unseen() Hand {
+	unseen := difference(Hand(NewDeck()), v.Seen())
+	slices.SortFunc(unseen, func(a, b Card) int { return a.Index() - b.Index() })
+	return unseen
+}
+
+// Probability a hand of held cards, drawn uniformly from unseen cards,
+// includes at least one of good cards
+func HoldProbability(unseen, good, held int) float64 {
+	if good <= 0 || held <= 0 {
+		return 0
+	}
+	none := 1.0
+	for i := range held {
+		if unseen-i <= 0 {
+			return 1
+		}
+		none *= float64(max(0, unseen-good-i)) / float64(unseen-i)
+	}
+	return 1 - none
+}
+
+// Chances of the opponent's reply to one of your plays
+type ReplyOdds struct {
+	Card      Card
+	Sum       int     // count after your Card
+	Fifteen   float64 // opponent can make 15
+	ThirtyOne float64
+	Pair      float64
+	Run       float64
+	Score     float64 // opponent can score anything
+	Go        float64 // opponent has no legal card
+}
+
+// Odds of each opponent reply for every legal play of the view, assuming
+// the opponent's remaining cards are any of the OpponentCards.
+// An opponent who said Go on this pile cannot reply.
+func TrackReplies(view PlayerView) []ReplyOdds {
+	pool := view.OpponentCards()
+	held := view.Remaining[view.Opponent()]
+	var odds []ReplyOdds
+
+	for _, card := range LegalPlays(view.PegState, view.Hand) {
+		state := afterPlay(view.PegState, card, view.Seat)
+		o := ReplyOdds{Card: card, Sum: state.Sum}
+		if state.Sum == 31 {
+			// the opponent leads a new pile
+			odds = append(odds, o)
+			continue
+		}
+		if state.Passed[view.Opponent()] {
+			o.Go = 1
+			odds = append(odds, o)
+			continue
+		}
+
+		var fifteen, thirtyOne, pair, run, score, blocked int
+		for _, reply := range pool {
+			sum := state.Sum + reply.ValueMax10()
+			if sum > 31 {
+				blocked++
+				continue
+			}
+			pile := append(slices.Clip(state.CardPile), reply)
+			points, _ := ScorePeggingPlay(state, reply)
+			if sum == 15 {
+				fifteen++
+			}
+			if sum == 31 {
+				thirtyOne++
+			}
+			if ScorePegPairs(pile) > 0 {
+				pair++
+			}
+			if ScorePegRuns(pile) > 0 {
+				run++
+			}
+			if points > 0 {
+				score++
+			}
+		}
+
+		n := len(pool)
+		o.Fifteen = HoldProbability(n, fifteen, held)
+		o.ThirtyOne = HoldProbability(n, thirtyOne, held)
+		o.Pair = HoldProbability(n, pair, held)
+		o.Run = HoldProbability(n, run, held)
+		o.Score = HoldProbability(n, score, held)
+		o.Go = 1 - HoldProbability(n, n-blocked, held)
+		odds = append(odds, o)
+	}
+	return odds
+}
+
+func (v PlayerView) PrintTracker() {
+	msg := "--- UNSEEN CARDS ---"
+	fmt.Println(msg)
+	unseen := v.Unseen()
+	fmt.Printf("%d unseen: %s\n", len(unseen), unseen)
+
+	counts := make(map[Rank]int)
+	for _, card := range unseen {
+		counts[card.Rank]++
+	}
+	var ranks []string
+	for r := Ace; r <= King; r++ {
+		ranks = append(ranks, fmt.Sprintf("%s:%d", r, counts[r]))
+	}
+	fmt.Println(strings.Join(ranks, " "))
+
+	held := v.Remaining[v.Opponent()]
+	fmt.Printf("Opponent holds %d card(s)\n", held)
+	if held > 0 {
+		for _, o := range TrackReplies(v) {
+			if o.Sum == 31 {
+				fmt.Printf("%-4s -> 31, the opponent leads a new pile\n", o.Card)
+				continue
+			}
+			fmt.Printf("%-4s -> %2d: P(15) %3.0f%%  P(31) %3.0f%%  P(pair) %3.0f%%  P(run) %3.0f%%  P(any score) %3.0f%%  P(Go) %3.0f%%\n",
+				o.Card, o.Sum, 100*o.Fifteen, 100*o.ThirtyOne, 100*o.Pair, 100*o.Run, 100*o.Score, 100*o.Go)
+		}
+	}
+	fmt.Println(strings.Repeat("-", len(msg)))
+}
@@ -0,0 +1,61 @@
+package cribbage
+
+import (
+	"math"
+	"testing"
+)
+
+func TestHoldProbability(t *testing.T) {
+	tests := []struct {
+		unseen, good, held int
+		want               float64
+	}{
+		{50, 3, 1, 0.06},
+		{40, 0, 4, 0},
+		{40, 40, 4, 1},
+		// 1 - (36/40)(35/39)(34/38)(33/37)
+		{40, 4, 4, 1 - 36.0*35*34*33/(40*39*38*37)},
+	}
+	for _, tt := range tests {
+		if got := HoldProbability(tt.unseen, tt.good, tt.held); math.Abs(got-tt.want) > 1e-9 {
+			t.Fatalf("HoldProbability(%d, %d, %d) = %f, want %f", tt.unseen, tt.good, tt.held, got, tt.want)
+		}
+	}
+}
+
+func TestTrackReplies(t *testing.T) {
+	ten, five := Card{Ten, Spades, Black}, Card{Five, Hearts, Red}
+	view := PlayerView{
+		PegState:  PegState{Sum: 10, CardPile: Hand{ten}, LastPlayer: 1},
+		Hand:      Hand{five},
+		Dealer:    1,
+		Remaining: [2]int{1, 1},
+	}
+	view.Played[1] = Hand{ten}
+
+	odds := TrackReplies(view)
+	// 5 on 10 makes 15: 3 unseen fives pair it, every card can be played on 15
+	if len(odds) != 1 || odds[0].Sum != 15 || math.Abs(odds[0].Pair-0.06) > 1e-9 || odds[0].Go != 0 || odds[0].Fifteen != 0 {
+		t.Fatalf("got %+v", odds)
+	}
+}
+
+// K Q, the opponent says Go at 20 and I play a 5: no reply can score
+func TestTrackReplies_OpponentGo(t *testing.T) {
+	pile, _ := ParseHand("KC QD")
+	five := Card{Five, Hearts, Red}
+	view := PlayerView{
+		PegState:  PegState{Sum: 20, CardPile: pile, LastPlayer: 0, Passed: [2]bool{false, true}},
+		Hand:      Hand{five, {Ace, Spades, Black}},
+		Remaining: [2]int{2, 3},
+	}
+	view.Played[0] = Hand{pile[0]}
+	view.Played[1] = Hand{pile[1]}
+	view.Goes[1] = []PegGo{{Count: 20}}
+
+	for _, o := range TrackReplies(view) {
+		if o.Go != 1 || o.Score != 0 || o.Pair != 0 || o.ThirtyOne != 0 || o.Fifteen != 0 || o.Run != 0 {
+			t.Fatalf("reply to %s after a Go: %+v", o.Card, o)
+		}
+	}
+}
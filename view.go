@@ -0,0 +1,75 @@
+package cribbage
+
+// File contains the PlayerView: everything a seated Player can see when deciding
+
+// Public board plus the deciding Player's own cards.
+// Built by the Game for every Discard and PlayPegCard decision.
+type PlayerView struct {
+	PegState          // current pile and count (zero value during the discard)
+	Seat      int     // index of the deciding Player
+	Hand      Hand    // own cards: 6 dealt cards, then the remaining PegHand
+	Discarded Hand    // own cards in the crib, after the discard
+	Played    [2]Hand // cards each Player has pegged this round, across all piles
+	Piles     []Hand  // finished piles of this round, oldest first
+	Goes      [2][]PegGo
+	Cut       Card
+	CutShown  bool // false until both Players discard
+	Scores    [2]int
+	Dealer    int
+	Remaining [2]int // cards left in each Player's hand
+}
+
+func (v PlayerView) IsDealer() bool {
+	return v.Seat == v.Dealer
+}
+
+func (v PlayerView) Opponent() int {
+	return 1 - v.Seat
+}
+
+// every card this Player knows is not in the opponent's hand
+func (v PlayerView) Seen() Hand {
+	seen := make(Hand, 0, 13)
+	seen = append(seen, v.Hand...)
+	seen = append(seen, v.Discarded...)
+	if v.CutShown {
+		seen = append(seen, v.Cut)
+	}
+	seen = append(seen, v.Played[0]...)
+	seen = append(seen, v.Played[1]...)
+	return seen
+}
+
+// snapshot of the board for the Player at seat
+func (game *Game) View(seat int, state PegState) PlayerView {
+	view := PlayerView{
+		PegState: state,
+		Seat:     seat,
+		Cut:      game.Cut,
+		CutShown: game.CutShown,
+		Dealer:   game.Dealer,
+		Piles:    make([]Hand, len(game.Piles)),
+	}
+	copy(view.Piles, game.Piles)
+	view.PegState.CardPile = append(Hand{}, state.CardPile...)
+
+	for i, player := range game.Players {
+		view.Scores[i] = player.GetScore()
+		view.Played[i] = append(Hand{}, game.Played[i]...)
+		view.Goes[i] = append([]PegGo{}, game.Goes[i]...)
+		// before the cut the dealt Hand is held, then the PegHand
+		if game.CutShown {
+			view.Remaining[i] = len(player.GetPegHand())
+		} else {
+			view.Remaining[i] = len(player.GetHand())
+		}
+	}
+
+	view.Discarded = append(Hand{}, game.Discards[seat]...)
+	if game.CutShown {
+		view.Hand = append(Hand{}, game.Players[seat].GetPegHand()...)
+	} else {
+		view.Hand = append(Hand{}, game.Players[seat].GetHand()...)
+	}
+	return view
+}
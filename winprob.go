@@ -0,0 +1,315 @@
+package cribbage
+
+// File contains a win probability model for score-aware ("positional") play
+
+import (
+	"math"
+	"math/rand"
+	"sync"
+)
+
+// Points scored in a typical hand by each role, and the resulting
+// probability that the pone wins from every score at the start of a hand.
+type WinModel struct {
+	PonePeg    Dist // pegging points of the pone
+	DealerPeg  Dist // pegging points (and His Heels) of the dealer
+	PoneHand   Dist
+	DealerHand Dist
+	Crib       Dist
+
+	// start[a][b]: pone with a points beats the dealer with b points
+	start [121][121]float64
+	// afterPeg[a][b]: same, once both Players have pegged
+	afterPeg [121][121]float64
+}
+
+var (
+	defaultWinModel     *WinModel
+	defaultWinModelOnce sync.Once
+)
+
+// WinModel of 2000 deals, built on first use
+func DefaultWinModel() *WinModel {
+	defaultWinModelOnce.Do(func() {
+		defaultWinModel = NewWinModel(fixedRand(121), 2000)
+	})
+	return defaultWinModel
+}
+
+// Simulate deals hands between two computer players to measure
+// the point distributions, then solve the win probability tables.
+func NewWinModel(rng *rand.Rand, deals int) *WinModel {
+	var ponePeg, dealerPeg, poneHand, dealerHand, crib []int
+	deck := NewDeck()
+	greedy := [2]PegChooser{GreedyPegChooser, GreedyPegChooser}
+
+	for range deals {
+		deck.Shuffle(rng)
+		// player 0 is the pone and player 1 the dealer
+		dealt0, dealt1, rest := Deal(deck, 6)
+		pone := OptimalDiscard(dealt0.Split(4), false)
+		dealer := OptimalDiscard(dealt1.Split(4), true)
+		cut := rest[0]
+
+		state := PegState{Turn: 0, CardPile: Hand{}}
+		pegged := PegPlayout(state, [2]Hand{pone.Keep, dealer.Keep}, greedy)
+		if cut.Rank == Jack {
+			pegged[1] += 2
+		}
+		ponePeg = addCount(ponePeg, pegged[0])
+		dealerPeg = addCount(dealerPeg, pegged[1])
+
+		poneHand = addCount(poneHand, pone.Keep.Score(cut, false))
+		dealerHand = addCount(dealerHand, dealer.Keep.Score(cut, false))
+		cribHand := append(append(Hand{}, pone.Discard...), dealer.Discard...)
+		crib = addCount(crib, cribHand.Score(cut, true))
+	}
+
+	m := &WinModel{
+		PonePeg:    distFromCounts(ponePeg),
+		DealerPeg:  distFromCounts(dealerPeg),
+		PoneHand:   distFromCounts(poneHand),
+		DealerHand: distFromCounts(dealerHand),
+		Crib:       distFromCounts(crib),
+	}
+	m.solve()
+	return m
+}
+
+// Value iteration over every score, from the highest totals down.
+// A hand can score zero points for both Players, so repeat until stable.
+func (m *WinModel) solve() {
+	dealerShow := m.DealerHand.Convolve(m.Crib)
+	for a := range 121 {
+		for b := range 121 {
+			m.start[a][b] = 0.5
+		}
+	}
+
+	for range 200 {
+		change := 0.0
+		for total := 240; total >= 0; total-- {
+			for a := max(0, total-120); a <= min(120, total); a++ {
+				b := total - a
+				m.afterPeg[a][b] = m.showWin(a, b, m.PoneHand, dealerShow)
+				p := m.pegWinTable(a, b)
+				change = max(change, math.Abs(p-m.start[a][b]))
+				m.start[a][b] = p
+			}
+		}
+		if change < 1e-9 {
+			break
+		}
+	}
+}
+
+// pone at a points wins from the start of the hand, before pegging
+func (m *WinModel) pegWin(a, b int, ponePeg, dealerPeg, poneHand, dealerShow Dist) float64 {
+	win := 0.0
+	for x, px := range ponePeg {
+		if px == 0 {
+			continue
+		}
+		if a+x >= 121 {
+			win += px
+			continue
+		}
+		for y, py := range dealerPeg {
+			if py == 0 || b+y >= 121 {
+				continue
+			}
+			win += px * py * m.showWin(a+x, b+y, poneHand, dealerShow)
+		}
+	}
+	return win
+}
+
+// pegWin for the average hands, looked up in the afterPeg table
+func (m *WinModel) pegWinTable(a, b int) float64 {
+	win := 0.0
+	for x, px := range m.PonePeg {
+		if a+x >= 121 {
+			win += px
+			continue
+		}
+		for y, py := range m.DealerPeg {
+			if b+y < 121 {
+				win += px * py * m.afterPeg[a+x][b+y]
+			}
+		}
+	}
+	return win
+}
+
+// pone at a points wins from the Show: pone counts first, then the dealer's hand and crib
+func (m *WinModel) showWin(a, b int, poneHand, dealerShow Dist) float64 {
+	win := 0.0
+	for h, ph := range poneHand {
+		if ph == 0 {
+			continue
+		}
+		if a+h >= 121 {
+			win += ph
+			continue
+		}
+		for d, pd := range dealerShow {
+			if pd == 0 || b+d >= 121 {
+				continue
+			}
+			// roles switch for the next hand
+			win += ph * pd * (1 - m.start[b+d][a+h])
+		}
+	}
+	return win
+}
+
+// Probability of winning from the start of a hand (before the discard)
+func (m *WinModel) WinProb(me, opp int, isDealer bool) float64 {
+	switch {
+	case me >= 121:
+		return 1
+	case opp >= 121:
+		return 0
+	case isDealer:
+		return 1 - m.start[opp][me]
+	default:
+		return m.start[me][opp]
+	}
+}
+
+// Probability of winning once pegging is finished, before the Show
+func (m *WinModel) ShowWinProb(me, opp int, isDealer bool) float64 {
+	switch {
+	case me >= 121:
+		return 1
+	case opp >= 121:
+		return 0
+	case isDealer:
+		return 1 - m.afterPeg[opp][me]
+	default:
+		return m.afterPeg[me][opp]
+	}
+}
+
+// Probability of winning in the middle of pegging, with myCards and oppCards
+// still to play. Each Player is credited the pegging points their role
+// averages for those cards before the ShowWinProb lookup.
+func (m *WinModel) PeggingWinProb(me, opp int, isDealer bool, myCards, oppCards int) float64 {
+	me += int(math.Round(m.pegAverage(isDealer) * float64(myCards) / 4))
+	opp += int(math.Round(m.pegAverage(!isDealer) * float64(oppCards) / 4))
+	return m.ShowWinProb(me, opp, isDealer)
+}
+
+// average points pegged in the play by the dealer or the pone
+func (m *WinModel) pegAverage(isDealer bool) float64 {
+	if isDealer {
+		// His Heels is in DealerPeg but is scored before the play
+		return m.DealerPeg.Mean() - 2.0*4/52
+	}
+	return m.PonePeg.Mean()
+}
+
+// Probability of winning the game after this discard, from the scores in the view.
+// peg is the pegging value of the option: the pegging points of both Players
+// move from the average of their role by it. A nil peg keeps the averages.
+// The crib is the CribDist of the option, with the opponent's throws
+// weighted by DefaultThrowModel.
+func (m *WinModel) DiscardWinProb(opt DiscardOption, me, opp int, isDealer bool, peg *PegValue) float64 {
+	return m.discardWinProb(opt, opt.CribDist(isDealer), me, opp, isDealer, peg)
+}
+
+func (m *WinModel) discardWinProb(opt DiscardOption, crib Dist, me, opp int, isDealer bool, peg *PegValue) float64 {
+	ponePeg, dealerPeg := m.PonePeg, m.DealerPeg
+	if peg != nil {
+		mine, theirs := &ponePeg, &dealerPeg
+		if isDealer {
+			mine, theirs = &dealerPeg, &ponePeg
+		}
+		*mine = mine.Shift(int(math.Round(peg.Earned - m.pegAverage(isDealer))))
+		*theirs = theirs.Shift(int(math.Round(peg.Conceded - m.pegAverage(!isDealer))))
+	}
+
+	if isDealer {
+		show := opt.HandDist().Convolve(crib)
+		return 1 - m.pegWin(opp, me, ponePeg, dealerPeg, m.PoneHand, show)
+	}
+	return m.pegWin(me, opp, ponePeg, dealerPeg, opt.HandDist(), m.DealerHand.Convolve(crib))
+}
+
+// Discard with the best probability of winning the game, including the
+// pegging value of each Keep against the same sampled opponent hands.
+// Far from 121 this is close to the best TotalEval, near the end
+// it trades points for safety (or for a chance to count out).
+func PositionalDiscard(options []DiscardOption, view PlayerView, m *WinModel) DiscardOption {
+	me, opp := view.Scores[view.Seat], view.Scores[view.Opponent()]
+	hands := sampleOpponentHands(view.Hand, pegValueSamples, view.Rand())
+	best := options[0]
+	bestWin, bestEV := -1.0, 0.0
+
+	for _, option := range options {
+		peg := option.PegValue(view.IsDealer(), hands)
+		crib := option.CribDist(view.IsDealer())
+		win := m.discardWinProb(option, crib, me, opp, view.IsDealer(), &peg)
+		// break ties (such as a decided game) by points, the crib counts against the pone
+		cribPoints := crib.Mean()
+		if !view.IsDealer() {
+			cribPoints = -cribPoints
+		}
+		ev := option.HandDist().Mean() + cribPoints + peg.Net()
+		if win > bestWin+1e-12 || (math.Abs(win-bestWin) <= 1e-12 && ev > bestEV) {
+			best, bestWin, bestEV = option, win, ev
+		}
+	}
+	return best
+}
+
+// Pegging play with the best probability of winning the game.
+// Each legal Card is scored by the points it makes and the points the
+// opponent's best reply would make. Without a belief every unseen Card is an
+// equally likely single reply, else each hand of the belief replies with its best Card.
+func PositionalPegging(view PlayerView, m *WinModel, belief *HandBelief) (Card, bool) {
+	legal := LegalPlays(view.PegState, view.Hand)
+	if len(legal) == 0 {
+		return Card{}, false
+	}
+	me, opp := view.Scores[view.Seat], view.Scores[view.Opponent()]
+	unseen := difference(Hand(NewDeck()), view.Seen())
+	// cards left to peg after this play and the opponent's reply
+	myCards := len(view.Hand) - 1
+	oppCards := max(view.Remaining[view.Opponent()]-1, 0)
+
+	best := legal[0]
+	bestWin, bestPoints := -1.0, -1
+	for _, card := range legal {
+		points, _ := ScorePeggingPlay(view.PegState, card)
+		win := 1.0
+		if me+points < 121 && len(unseen) > 0 {
+			after := view.PegState
+			after.CardPile = append(Hand{}, view.CardPile...)
+			after.mustAddCard(card)
+			after.Turn = view.Opponent()
+
+			win = 0
+			if belief != nil {
+				for i, hand := range belief.Hands {
+					reply := replyPoints(after, hand, view.Opponent())
+					win += belief.Weights[i] * m.PeggingWinProb(me+points, opp+reply, view.IsDealer(), myCards, oppCards)
+				}
+			} else {
+				for _, reply := range unseen {
+					replyPoints := 0
+					if view.Remaining[view.Opponent()] > 0 && !after.Passed[view.Opponent()] && reply.ValueMax10() <= 31-after.Sum {
+						replyPoints, _ = ScorePeggingPlay(after, reply)
+					}
+					win += m.PeggingWinProb(me+points, opp+replyPoints, view.IsDealer(), myCards, oppCards)
+				}
+				win /= float64(len(unseen))
+			}
+		}
+
+		if win > bestWin+1e-12 || (math.Abs(win-bestWin) <= 1e-12 && points > bestPoints) {
+			best, bestWin, bestPoints = card, win, points
+		}
+	}
+	return best, true
+}
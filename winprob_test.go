@@ -0,0 +1,92 @@
+package cribbage
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func testWinModel() *WinModel {
+	return NewWinModel(rand.New(rand.NewSource(1)), 200)
+}
+
+func TestWinModel_WinProb(t *testing.T) {
+	m := testWinModel()
+
+	dealer, pone := m.WinProb(0, 0, true), m.WinProb(0, 0, false)
+	if dealer <= 0.5 || dealer+pone < 0.999 || dealer+pone > 1.001 {
+		t.Fatalf("first deal: dealer %f, pone %f", dealer, pone)
+	}
+	if p := m.WinProb(120, 0, false); p < 0.99 {
+		t.Fatalf("pone needing 1 point wins %f", p)
+	}
+	if m.WinProb(100, 60, true) <= m.WinProb(60, 100, true) {
+		t.Fatalf("leading is not better than trailing")
+	}
+}
+
+// Pegging out wins the game even when another play scores more points later
+func TestPositionalPegging_PegOut(t *testing.T) {
+	m := testWinModel()
+	view := PlayerView{
+		PegState:  PegState{Sum: 10, Turn: 0, CardPile: Hand{{Rank: King, Suit: Clubs}}},
+		Seat:      0,
+		Hand:      Hand{{Rank: Nine, Suit: Hearts}, {Rank: Five, Suit: Spades}},
+		Scores:    [2]int{119, 90},
+		Dealer:    1,
+		Remaining: [2]int{2, 3},
+	}
+
+	card, ok := PositionalPegging(view, m, nil)
+	if !ok || card.Rank != Five {
+		t.Fatalf("got %s, want 5♠", card)
+	}
+}
+
+// Cards still to peg are worth points, so they change the win probability
+func TestWinModel_PeggingWinProb(t *testing.T) {
+	m := testWinModel()
+	if got, want := m.PeggingWinProb(100, 100, false, 0, 0), m.ShowWinProb(100, 100, false); got != want {
+		t.Fatalf("no cards left: %f, ShowWinProb %f", got, want)
+	}
+	if m.PeggingWinProb(110, 110, false, 3, 0) <= m.PeggingWinProb(110, 110, false, 0, 3) {
+		t.Fatalf("pegging 3 more cards is not better than the opponent pegging them")
+	}
+}
+
+// A Keep that pegs well wins more often than the same Keep at the role's average
+func TestWinModel_DiscardWinProb_Peg(t *testing.T) {
+	m := testWinModel()
+	dealt, _ := ParseHand("5H 5S 6D 7C KD QH")
+	opt := dealt.Split(4)[0]
+
+	average := m.DiscardWinProb(opt, 90, 90, false, nil)
+	pegs := m.DiscardWinProb(opt, 90, 90, false, &PegValue{Earned: 8, Conceded: 1})
+	if pegs <= average {
+		t.Fatalf("win %.3f with a strong pegging Keep, %.3f on average", pegs, average)
+	}
+}
+
+// The pone's win probability counts the crib of the cards actually thrown
+func TestWinModel_DiscardWinProb_Crib(t *testing.T) {
+	m := testWinModel()
+	dealt, _ := ParseHand("5H 5S 6D 7C KD 9H")
+	var fives, kingNine DiscardOption
+	for _, opt := range dealt.Split(4) {
+		switch opt.Discard.Set() {
+		case Hand{dealt[0], dealt[1]}.Set():
+			fives = opt
+		case Hand{dealt[4], dealt[5]}.Set():
+			kingNine = opt
+		}
+	}
+	// same pegging for both, only the Keep and the crib differ
+	peg := &PegValue{}
+	fivesCrib := fives.CribDist(false).Mean()
+	kingNineCrib := kingNine.CribDist(false).Mean()
+	if fivesCrib <= kingNineCrib {
+		t.Fatalf("crib of 5 5 %.2f, of K 9 %.2f", fivesCrib, kingNineCrib)
+	}
+	if m.DiscardWinProb(fives, 60, 60, false, peg) >= m.DiscardWinProb(kingNine, 60, 60, false, peg) {
+		t.Fatalf("throwing 5 5 to the dealer is not worse than K 9")
+	}
+}